@@ -0,0 +1,94 @@
+// auto.go - Let the solver play against a word it is told, working out
+// each response itself with evaluateGuess.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// If false (the default), the solver declares the answer as soon as only
+// one word fits the clues, without spending a guess to confirm it.
+// Set by --confirm.
+var confirmAnswer = false
+
+// Responses to give the solver in place of the true ones, keyed by guess
+// number (counting from 1), to see how it copes with a host that lies.
+// Set by --inject.
+var injectedResponses map[int]string
+
+// Parse --inject: comma-separated step:response pairs, such as 2:nnpny.
+func parseInjections(spec string) (map[int]string, error) {
+	injections := make(map[int]string)
+	for _, item := range strings.Split(spec, ",") {
+		fields := strings.Split(item, ":")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("--inject entries must look like step:response, not %v", item)
+		}
+		step, err := strconv.Atoi(fields[0])
+		if err != nil || step < 1 {
+			return nil, fmt.Errorf("--inject step must be a guess number from 1, not %v", fields[0])
+		}
+		response := strings.ToLower(fields[1])
+		if len(response) != LETTERS_IN_WORD || strings.Trim(response, "ypn") != "" {
+			return nil, fmt.Errorf("--inject response must be %v of y, p and n, not %v", LETTERS_IN_WORD, fields[1])
+		}
+		injections[step] = response
+	}
+	return injections, nil
+}
+
+// Solve target without any help from the user.  Return the number of
+// guesses made, and whether the solver found the word.
+// If printGuesses is true, each guess and its response is printed.
+func solveTarget(target string, printGuesses bool) (int, bool) {
+	solver := NewSolver()
+	numGuesses := 0
+	for !solver.solved {
+		if !confirmAnswer {
+			if answer := solver.onlyCandidate(); len(answer) > 0 {
+				if printGuesses {
+					fmt.Println("Only " + answer + " fits the clues")
+				}
+				return numGuesses, answer == target
+			}
+		}
+		myGuess := solver.chooseGuess()
+		if len(myGuess) == 0 {
+			// The clues have ruled out every word, including the target.
+			return numGuesses, false
+		}
+		numGuesses++
+		response := evaluateGuess(myGuess, target)
+		injected, isInjected := injectedResponses[numGuesses]
+		if printGuesses {
+			fmt.Println(myGuess)
+			if isInjected {
+				fmt.Println("Resp: " + injected + " (injected; the true response is " + response + ")")
+			} else {
+				fmt.Println("Resp: " + response)
+			}
+		}
+		if isInjected {
+			response = injected
+		}
+		if _, err := solver.processResponse(myGuess, response); err != nil && printGuesses {
+			fmt.Println(err)
+		}
+	}
+	// An injected yyyyy can make the solver settle on the wrong word.
+	return numGuesses, solver.answer == target
+}
+
+// Auto mode: solve target and report the result.
+func autoSolve(target string, finalOnly bool) bool {
+	numGuesses, solved := solveTarget(target, !finalOnly)
+	if solved {
+		fmt.Printf("Solved %v in %v guesses\n", target, numGuesses)
+	} else {
+		fmt.Printf("Could not solve %v; gave up after %v guesses\n", target, numGuesses)
+	}
+	return solved
+}
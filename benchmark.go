@@ -0,0 +1,235 @@
+// benchmark.go - Run the solver against every word in the list, to see
+// how well it does.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The number of guesses the solver is allowed, as in Wordle.  A word that
+// takes more guesses than this counts as a failure.
+const MAX_GUESSES = 6
+
+// How the solver did against one word.
+type BenchmarkResult struct {
+	word       string
+	numGuesses int
+	// True if the solver found the word, no matter how many guesses it took.
+	solved bool
+}
+
+// Return true if the solver failed on this word: either it never found
+// it, or it took more than MAX_GUESSES guesses.
+func (result BenchmarkResult) failed() bool {
+	return !result.solved || result.numGuesses > MAX_GUESSES
+}
+
+// Solve each of targets automatically, and return the results in the
+// same order.
+func runBenchmark(targets []string) []BenchmarkResult {
+	results := make([]BenchmarkResult, len(targets))
+	for i, target := range targets {
+		numGuesses, solved := solveTarget(target, false)
+		results[i] = BenchmarkResult{word: target, numGuesses: numGuesses, solved: solved}
+	}
+	return results
+}
+
+// Sort results so that the words the solver found hardest come first:
+// words it never solved, then by decreasing number of guesses.
+func sortHardestFirst(results []BenchmarkResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].solved != results[j].solved {
+			return !results[i].solved
+		}
+		if results[i].numGuesses != results[j].numGuesses {
+			return results[i].numGuesses > results[j].numGuesses
+		}
+		return results[i].word < results[j].word
+	})
+}
+
+// Report the numWords words that take the solver the most guesses.
+func showHardest(numWords int) {
+	results := runBenchmark(AllWords)
+	sortHardestFirst(results)
+	if numWords > len(results) {
+		numWords = len(results)
+	}
+	for _, result := range results[:numWords] {
+		if result.solved {
+			fmt.Printf("%v  %v\n", result.word, result.numGuesses)
+		} else {
+			fmt.Printf("%v  not solved\n", result.word)
+		}
+	}
+}
+
+// Run the solver against every word in the list, and list the words it
+// can't find within maxGuesses guesses, hardest first.  The guess of the
+// last remaining word is counted, as in Wordle, since this is meant as a
+// guarantee rather than a measure.  Return true if every word was found.
+func proveSolvable(maxGuesses int) bool {
+	savedConfirm := confirmAnswer
+	confirmAnswer = true
+	defer func() { confirmAnswer = savedConfirm }()
+	results := runBenchmark(AllWords)
+	sortHardestFirst(results)
+	numFailed := 0
+	for _, result := range results {
+		if result.solved && result.numGuesses <= maxGuesses {
+			break
+		}
+		numFailed++
+		if result.solved {
+			fmt.Printf("%v  %v guesses\n", result.word, result.numGuesses)
+		} else {
+			fmt.Printf("%v  not solved\n", result.word)
+		}
+	}
+	fmt.Printf("Solved %v of %v words within %v guesses\n", len(results)-numFailed, len(results), maxGuesses)
+	return numFailed == 0
+}
+
+// Load a word frequency file.  Each non-blank line holds a word and a
+// count of how often it is used; lines starting with # are comments.
+func loadFrequencies(path string) (map[string]int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	frequencies := make(map[string]int)
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%v line %v: expected a word and a count", path, lineNum)
+		}
+		count, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%v line %v: bad count %v", path, lineNum, fields[1])
+		}
+		frequencies[fields[0]] = count
+	}
+	return frequencies, scanner.Err()
+}
+
+// The overall results of a benchmark.  average and worst count only the
+// words the solver found.
+type BenchmarkSummary struct {
+	numWords  int
+	numFailed int
+	worst     int
+	average   float64
+}
+
+func summarize(results []BenchmarkResult) BenchmarkSummary {
+	summary := BenchmarkSummary{numWords: len(results)}
+	totalGuesses := 0
+	numFound := 0
+	for _, result := range results {
+		if result.failed() {
+			summary.numFailed++
+		}
+		if result.solved {
+			numFound++
+			totalGuesses += result.numGuesses
+			if result.numGuesses > summary.worst {
+				summary.worst = result.numGuesses
+			}
+		}
+	}
+	if numFound > 0 {
+		summary.average = float64(totalGuesses) / float64(numFound)
+	}
+	return summary
+}
+
+// Print the average and worst number of guesses in results, counting
+// only the words the solver found, and the number of failures.
+func printBenchmarkSummary(results []BenchmarkResult) {
+	summary := summarize(results)
+	fmt.Printf("Solved %v of %v words within %v guesses\n",
+		summary.numWords-summary.numFailed, summary.numWords, MAX_GUESSES)
+	fmt.Printf("Average %.3f guesses; worst %v; failures %v\n", summary.average, summary.worst, summary.numFailed)
+}
+
+// Return sampleSize words chosen at random from words, or all of words if
+// sampleSize is 0 or at least the number of words.
+func sampleWords(words []string, sampleSize int) []string {
+	if sampleSize <= 0 || sampleSize >= len(words) {
+		return words
+	}
+	sample := make([]string, sampleSize)
+	for i, idx := range rng.Perm(len(words))[:sampleSize] {
+		sample[i] = words[idx]
+	}
+	return sample
+}
+
+// Return the words to benchmark against: every word in the list, or, if
+// freqFile is given, just the words used at least minFreq times according
+// to it.  Then take a random sample of sampleSize of those, if sampleSize
+// is not 0.
+func benchmarkTargets(freqFile string, minFreq int, sampleSize int) ([]string, error) {
+	targets := AllWords
+	if len(freqFile) > 0 {
+		frequencies, err := loadFrequencies(freqFile)
+		if err != nil {
+			return nil, err
+		}
+		targets = nil
+		for _, word := range AllWords {
+			if frequencies[word] >= minFreq {
+				targets = append(targets, word)
+			}
+		}
+		fmt.Printf("Using %v words with frequency at least %v; excluded %v\n",
+			len(targets), minFreq, len(AllWords)-len(targets))
+	}
+	targets = sampleWords(targets, sampleSize)
+	if sampleSize > 0 {
+		fmt.Printf("Using a sample of %v words\n", len(targets))
+	}
+	return targets, nil
+}
+
+// Run the solver against the words chosen by benchmarkTargets.
+func benchmark(freqFile string, minFreq int, sampleSize int) error {
+	targets, err := benchmarkTargets(freqFile, minFreq, sampleSize)
+	if err != nil {
+		return err
+	}
+	printBenchmarkSummary(runBenchmark(targets))
+	return nil
+}
+
+// Run the benchmark once for each strategy, and print the results side
+// by side.
+func compareStrategies(freqFile string, minFreq int, sampleSize int) error {
+	targets, err := benchmarkTargets(freqFile, minFreq, sampleSize)
+	if err != nil {
+		return err
+	}
+	savedStrategy := activeStrategy
+	defer func() { activeStrategy = savedStrategy }()
+	fmt.Printf("%-10v %8v %6v %9v\n", "strategy", "average", "worst", "failures")
+	for _, strategy := range STRATEGIES {
+		activeStrategy = strategy
+		summary := summarize(runBenchmark(targets))
+		fmt.Printf("%-10v %8.3f %6v %9v\n", strategy, summary.average, summary.worst, summary.numFailed)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// coach.go - Coaching notes for the player in run mode.  The coach
+// tracks the clues the player has been given, the same way the solver
+// does, and comments on each guess.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Coach struct {
+	// The clues the player has been given so far.
+	solver *Solver
+	// If true, note when an anagram of a guess would have been a better probe.
+	anagrams bool
+	// If true, warn when a guess can't win and gives no new information.
+	useless bool
+}
+
+func NewCoach(anagrams bool, useless bool) *Coach {
+	return &Coach{solver: NewSolver(), anagrams: anagrams, useless: useless}
+}
+
+// Return the letters of word in alphabetical order, so that words which
+// are anagrams of each other give the same result.
+func sortLetters(word string) string {
+	letters := strings.Split(word, "")
+	sort.Strings(letters)
+	return strings.Join(letters, "")
+}
+
+// Comment on a guess the player has just made, and record its response.
+func (coach *Coach) reviewGuess(guess string, response string) {
+	candidates := coach.solver.findCandidates()
+	if coach.useless && response != "yyyyy" && isUselessGuess(coach.solver, guess, candidates) {
+		fmt.Println("Coach: This guess can't win and gives no new information.")
+	}
+	if coach.anagrams && response != "yyyyy" && len(candidates) > 0 {
+		coach.noteBetterAnagram(guess, candidates)
+	}
+	coach.solver.processResponse(guess, response)
+	if !coach.solver.solved {
+		fmt.Printf("Coach: %v words still fit the clues\n", len(coach.solver.findCandidates()))
+	}
+}
+
+// Report whether guess can't be the word, given the clues solver has,
+// and would get the same response from every one of candidates, so that
+// it can't narrow them down.  With no candidates, as when the word isn't
+// in the word list, there is nothing to judge the guess by, so it is not
+// reported.
+func isUselessGuess(solver *Solver, guess string, candidates []string) bool {
+	return len(candidates) > 0 && !solver.matchesClues(guess) && len(responseBuckets(guess, candidates)) <= 1
+}
+
+// If some other arrangement of the letters in guess would have been
+// expected to leave fewer candidates, say so.  candidates are the words
+// that fit the clues before guess was made.
+func (coach *Coach) noteBetterAnagram(guess string, candidates []string) {
+	guessLetters := sortLetters(guess)
+	guessScore := expectedRemaining(responseBuckets(guess, candidates), len(candidates))
+	bestAnagram := ""
+	bestScore := guessScore
+	for _, word := range AllWords {
+		if word == guess || sortLetters(word) != guessLetters {
+			continue
+		}
+		score := expectedRemaining(responseBuckets(word, candidates), len(candidates))
+		if score < bestScore {
+			bestAnagram = word
+			bestScore = score
+		}
+	}
+	if len(bestAnagram) > 0 {
+		fmt.Printf("Coach: %v would have been a better probe than %v (expected %.1f words left instead of %.1f)\n",
+			bestAnagram, guess, bestScore, guessScore)
+	}
+}
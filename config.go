@@ -0,0 +1,104 @@
+// config.go - Read defaults for the command line flags from a config
+// file, so that a regular player doesn't have to give the same flags
+// every time.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// The environment variable naming the config file, if it isn't the
+// default.
+const CONFIG_ENV = "WORDG_CONFIG"
+
+// The config file in the user's home directory, if CONFIG_ENV isn't set.
+const CONFIG_FILE = ".wordg.json"
+
+// Return the path of the config file.
+func configPath() (string, error) {
+	if path := os.Getenv(CONFIG_ENV); len(path) > 0 {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, CONFIG_FILE), nil
+}
+
+// Set the flags in flags that the config file gives values for, other
+// than those in given, the flags set on the command line, which override
+// the file.  Skipping them, rather than letting the command line set them
+// again, matters for a flag such as --word that can be repeated.  The
+// file is a JSON object whose keys are flag names without the dashes,
+// such as {"strategy": "entropy", "max-guesses": 6, "symbols": true}.
+// The mode flags can't be set.  Flags in allFlags but not in flags, which
+// the subcommand doesn't accept, are skipped, so that one file can serve
+// every subcommand.  A missing file is not an error, unless it was named
+// by CONFIG_ENV.
+func applyConfig(flags *flag.FlagSet, allFlags *flag.FlagSet, given StringSet) error {
+	path, err := configPath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && len(os.Getenv(CONFIG_ENV)) == 0 {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("cannot read config file %v: %v", path, err)
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("config file %v is not a JSON object: %v", path, err)
+	}
+	for name, value := range values {
+		if allFlags.Lookup(name) == nil {
+			return fmt.Errorf("config file %v sets %v, which is not a flag", path, name)
+		}
+		for _, modeFlag := range MODE_FLAGS {
+			if name == modeFlag {
+				return fmt.Errorf("config file %v sets %v, which chooses the mode and can only be given on the command line", path, name)
+			}
+		}
+		if flags.Lookup(name) == nil || given.Contains(name) {
+			continue
+		}
+		text, err := configValue(value)
+		if err != nil {
+			return fmt.Errorf("config file %v: bad value for %v: %v", path, name, err)
+		}
+		if err := flags.Set(name, text); err != nil {
+			return fmt.Errorf("config file %v: bad value for %v: %v", path, name, err)
+		}
+	}
+	return nil
+}
+
+// Return the flag text for a value decoded from the config file.  JSON
+// numbers decode as float64, so a whole number is written without an
+// exponent, for the int flags.  Arrays, objects and null have no flag
+// text.
+func configValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		if v != math.Trunc(v) || math.IsInf(v, 0) {
+			return "", fmt.Errorf("%v is not a whole number", v)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("%v must be a string, true or false, or a whole number", v)
+	}
+}
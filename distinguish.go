@@ -0,0 +1,56 @@
+// distinguish.go - Find guesses that tell two words apart, for
+// --distinguish, when you're stuck between them.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The most guesses --distinguish lists besides the best one.
+const MAX_DISTINGUISHING_SHOWN = 10
+
+// Return the words in the list whose response differs between word1
+// and word2, so that guessing any of them tells which it is.  word1 and
+// word2 themselves come first, if they are in the list, since guessing
+// one of them might find the word outright.
+func distinguishingGuesses(word1 string, word2 string) []string {
+	var guesses []string
+	for _, word := range []string{word1, word2} {
+		if isKnownWord(word) {
+			guesses = append(guesses, word)
+		}
+	}
+	for _, guess := range AllWords {
+		if guess == word1 || guess == word2 {
+			continue
+		}
+		if evaluateGuess(guess, word1) != evaluateGuess(guess, word2) {
+			guesses = append(guesses, guess)
+		}
+	}
+	return guesses
+}
+
+// Print the best guess to tell word1 from word2, with the response it
+// gets from each, and some of the others.
+func distinguish(word1 string, word2 string) {
+	guesses := distinguishingGuesses(word1, word2)
+	if len(guesses) == 0 {
+		fmt.Printf("No word in the list tells %v from %v\n", word1, word2)
+		return
+	}
+	best := guesses[0]
+	fmt.Printf("Guess %v: %v gives %v, %v gives %v\n", best,
+		word1, evaluateGuess(best, word1), word2, evaluateGuess(best, word2))
+	others := guesses[1:]
+	if len(others) == 0 {
+		return
+	}
+	shown := others
+	if len(shown) > MAX_DISTINGUISHING_SHOWN {
+		shown = shown[:MAX_DISTINGUISHING_SHOWN]
+	}
+	fmt.Printf("%v other guesses also tell them apart, such as %v\n", len(others), strings.Join(shown, ", "))
+}
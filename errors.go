@@ -0,0 +1,91 @@
+// errors.go - The kinds of bad input the engine can reject, so that a
+// caller can tell them apart with errors.Is rather than by the message.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// A guess or response has the wrong number of letters.
+	ErrWrongLength = errors.New("wrong length")
+	// A guess is not in the word list.
+	ErrNotAWord = errors.New("not in the word list")
+	// A word has a letter that is not in the alphabet.
+	ErrBadLetter = errors.New("not in the alphabet")
+	// A response has a character other than y, p or n, or can't be right
+	// given the responses before it.
+	ErrInvalidResponse = errors.New("invalid response")
+)
+
+// An error of one of the kinds above, with a message for the user.
+type InputError struct {
+	kind    error
+	message string
+}
+
+func (err *InputError) Error() string {
+	return err.message
+}
+
+func (err *InputError) Unwrap() error {
+	return err.kind
+}
+
+func inputError(kind error, format string, args ...interface{}) error {
+	return &InputError{kind: kind, message: fmt.Sprintf(format, args...)}
+}
+
+// Check that guess has LETTERS_IN_WORD letters.
+func checkGuessLength(guess string) error {
+	numLetters := utf8.RuneCountInString(guess)
+	if numLetters < LETTERS_IN_WORD {
+		return inputError(ErrWrongLength, "%v is too short: guesses must be exactly %v letters", guess, LETTERS_IN_WORD)
+	} else if numLetters > LETTERS_IN_WORD {
+		return inputError(ErrWrongLength, "%v is too long: guesses must be exactly %v letters", guess, LETTERS_IN_WORD)
+	}
+	return nil
+}
+
+// Check that guess is in the word list.
+func checkKnownWord(guess string) error {
+	if !isKnownWord(guess) {
+		return inputError(ErrNotAWord, "%v is not a valid word", guess)
+	}
+	return nil
+}
+
+// Check that word can be the secret word in run mode: the right length,
+// and made of letters of the alphabet, so that guesses can be scored
+// against it.  It need not be in the word list.
+func checkSecretWord(word string) error {
+	if err := checkGuessLength(word); err != nil {
+		return inputError(ErrWrongLength, "The word %v must be exactly %v letters", word, LETTERS_IN_WORD)
+	}
+	if !inAlphabet(word) {
+		return inputError(ErrBadLetter, "The word %v has letters that are not in the alphabet %v", word, alphabet)
+	}
+	return nil
+}
+
+// Check that response has a y, p or n for each letter of a guess.
+func checkResponse(response string) error {
+	numChars := utf8.RuneCountInString(response)
+	if numChars > LETTERS_IN_WORD {
+		return inputError(ErrWrongLength, "Response is too long: expected %v characters but got %v (%v extra)",
+			LETTERS_IN_WORD, numChars, numChars-LETTERS_IN_WORD)
+	} else if numChars < LETTERS_IN_WORD {
+		return inputError(ErrWrongLength, "Response is too short: expected %v characters but got %v",
+			LETTERS_IN_WORD, numChars)
+	}
+	for _, ch := range response {
+		if !strings.ContainsRune("ypn", ch) {
+			return inputError(ErrInvalidResponse, "Unexpected response char: %c", ch)
+		}
+	}
+	return nil
+}
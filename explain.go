@@ -0,0 +1,64 @@
+// explain.go - Narrate the solver's reasoning in plain English, for
+// --explain.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Describe in words what response says about the letters of guess.
+func describeResponse(guess string, response string) string {
+	letters := []rune(guess)
+	// Letters marked y or p somewhere in this guess.  A gray for one of
+	// those means there are no more of it, rather than none at all.
+	marked := make(StringSet)
+	for j, letter := range letters {
+		if response[j:j+1] != "n" {
+			marked.Add(string(letter))
+		}
+	}
+	var clues []string
+	for j, letter := range letters {
+		ch := string(letter)
+		switch response[j : j+1] {
+		case "y":
+			clues = append(clues, fmt.Sprintf("'%v' is in position %v", ch, j+1))
+		case "p":
+			clues = append(clues, fmt.Sprintf("'%v' is in the word but not position %v", ch, j+1))
+		case "n":
+			if marked.Contains(ch) {
+				clues = append(clues, fmt.Sprintf("there are no more '%v's than that", ch))
+			} else {
+				clues = append(clues, fmt.Sprintf("'%v' is not in the word", ch))
+			}
+		}
+	}
+	if len(clues) > 1 {
+		clues[len(clues)-1] = "and " + clues[len(clues)-1]
+	}
+	return "You told me " + strings.Join(clues, ", ")
+}
+
+// Explain the response to guess, given how many words fitted the clues
+// before and after it.
+func explainResponse(guess string, response string, numBefore int, numAfter int) {
+	fmt.Printf("%v, so I eliminated %v words, leaving %v.\n",
+		describeResponse(guess, response), numBefore-numAfter, numAfter)
+}
+
+// Explain why the solver chose guess from candidates.
+func explainGuess(guess string, candidates []string) {
+	switch activeStrategy {
+	case "lookahead":
+		fmt.Printf("Looking a guess ahead, '%v' is expected to find the word in %.2f guesses, counting itself.\n",
+			guess, lookaheadCost(guess, candidates))
+	case "minimax":
+		fmt.Printf("The remaining guess with the best worst case is '%v'.\n", guess)
+	case "entropy":
+		fmt.Printf("The most informative remaining guess is '%v'.\n", guess)
+	default:
+		fmt.Printf("The first remaining word in my list is '%v'.\n", guess)
+	}
+}
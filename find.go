@@ -0,0 +1,54 @@
+// find.go - List the words that match a pattern, without playing.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// Return a Solver whose clues say that the word matches pattern (letters
+// in known positions, with _ or . for unknown ones), contains each letter
+// of contains (as many times as it appears there), and has none of the
+// letters of exclude.
+func solverForPattern(pattern string, contains string, exclude string) (*Solver, error) {
+	solver := NewSolver()
+	if len(pattern) > 0 {
+		if utf8.RuneCountInString(pattern) != LETTERS_IN_WORD {
+			return nil, fmt.Errorf("the pattern must be %v letters long", LETTERS_IN_WORD)
+		}
+		for ipos, letter := range []rune(pattern) {
+			if letter != '_' && letter != '.' {
+				solver.validLetters[ipos].RemoveAll()
+				solver.validLetters[ipos].Add(string(letter))
+			}
+		}
+	}
+	for letter, count := range makeMapFromWord(contains) {
+		solver.requiredLetters[letter] = count
+	}
+	for _, letter := range exclude {
+		for ipos := range solver.validLetters {
+			solver.validLetters[ipos].Remove(string(letter))
+		}
+	}
+	return solver, nil
+}
+
+// Print, in alphabetical order, every word that matches the pattern,
+// contains and exclude (see solverForPattern), then how many there were.
+// Return an error if they are malformed.
+func findWords(pattern string, contains string, exclude string) error {
+	solver, err := solverForPattern(pattern, contains, exclude)
+	if err != nil {
+		return err
+	}
+	words := solver.findCandidates()
+	sort.Strings(words)
+	for _, word := range words {
+		fmt.Println(word)
+	}
+	fmt.Printf("%v words found\n", len(words))
+	return nil
+}
@@ -0,0 +1,96 @@
+// fromshare.go - Work back from a shared Wordle result, and the guesses
+// that were made, to the words that could have been the answer.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// The response each colored square stands for.  Besides the usual
+// squares, this includes white for light mode, and orange and blue for
+// Wordle's high contrast mode.
+var responseForEmoji = map[rune]string{
+	'🟩': "y", '🟨': "p", '⬛': "n",
+	'⬜': "n", '🟧': "y", '🟦': "p",
+}
+
+// Return the responses in a pasted share block, one for each line that
+// is a row of LETTERS_IN_WORD squares.  Other lines, such as the
+// "Wordle 1,234 4/6" header, are skipped.
+func parseShare(text string) []string {
+	var responses []string
+	for _, line := range strings.Split(text, "\n") {
+		response := ""
+		for _, square := range strings.TrimSpace(line) {
+			if code, present := responseForEmoji[square]; present {
+				response += code
+			} else {
+				response = ""
+				break
+			}
+		}
+		if len(response) == LETTERS_IN_WORD {
+			responses = append(responses, response)
+		}
+	}
+	return responses
+}
+
+// Return the words that would have given the same response to every
+// guess in history.
+func wordsFitting(history []TranscriptEntry) []string {
+	var fitting []string
+	for _, word := range AllWords {
+		fits := true
+		for _, entry := range history {
+			if evaluateGuess(entry.guess, word) != entry.response {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			fitting = append(fitting, word)
+		}
+	}
+	return fitting
+}
+
+// Read a share block from path and list the words that could have been
+// the answer, given guesses, the words guessed for its first rows.
+func fromShare(path string, guesses []string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	responses := parseShare(string(data))
+	if len(responses) == 0 {
+		fmt.Println("No rows of colored squares found in " + path)
+		return nil
+	}
+	if len(guesses) > len(responses) {
+		fmt.Printf("There are %v guesses but only %v rows\n", len(guesses), len(responses))
+		return nil
+	}
+	var history []TranscriptEntry
+	for i, guess := range guesses {
+		fmt.Printf("%v  %v\n", guess, responses[i])
+		if responses[i] == "yyyyy" {
+			fmt.Println("The answer was " + guess)
+			return nil
+		}
+		history = append(history, TranscriptEntry{guess: guess, response: responses[i]})
+	}
+	candidates := wordsFitting(history)
+	if len(candidates) == 0 {
+		fmt.Println("No word fits those guesses and rows")
+	} else if len(candidates) > MAX_OTHER_ANSWERS_SHOWN {
+		fmt.Printf("%v words could have been the answer, including: %v\n",
+			len(candidates), strings.Join(candidates[:MAX_OTHER_ANSWERS_SHOWN], " "))
+	} else {
+		fmt.Printf("%v words could have been the answer: %v\n", len(candidates), strings.Join(candidates, " "))
+	}
+	return nil
+}
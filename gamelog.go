@@ -0,0 +1,85 @@
+// gamelog.go - Append a record of each finished game to a file, one JSON
+// object per line, for --log.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+type GameRecord struct {
+	// When the game ended, in RFC 3339 form.
+	Time    string
+	Mode    string
+	Secret  string
+	Guesses []string
+	Results []string
+	// won, lost (out of guesses) or quit.
+	Outcome    string
+	NumGuesses int
+	Seconds    float64
+	// The command line flags that were set, as name=value.
+	Flags []string
+}
+
+// Serializes appends to the log within this process.  Between processes,
+// each record is written with a single append, which the system doesn't
+// interleave with others.
+var gameLogMutex sync.Mutex
+
+// Return a record of a game that started at start, with its guesses and
+// their responses in history.
+func newGameRecord(mode string, secret string, history []TranscriptEntry, outcome string,
+	numGuesses int, start time.Time) GameRecord {
+	record := GameRecord{
+		Time:       time.Now().Format(time.RFC3339),
+		Mode:       mode,
+		Secret:     secret,
+		Outcome:    outcome,
+		NumGuesses: numGuesses,
+		Seconds:    time.Since(start).Seconds(),
+	}
+	for _, entry := range history {
+		record.Guesses = append(record.Guesses, entry.guess)
+		record.Results = append(record.Results, entry.response)
+	}
+	commandLine.Visit(func(f *flag.Flag) {
+		record.Flags = append(record.Flags, f.Name+"="+f.Value.String())
+	})
+	return record
+}
+
+// Append record to the log file at path, as one line of JSON.
+func appendGameLog(path string, record GameRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	gameLogMutex.Lock()
+	defer gameLogMutex.Unlock()
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Log a finished game to path, if it isn't empty.
+func logGame(path string, record GameRecord) error {
+	if len(path) == 0 {
+		return nil
+	}
+	if err := appendGameLog(path, record); err != nil {
+		return fmt.Errorf("Cannot write to the game log: %v", err)
+	}
+	return nil
+}
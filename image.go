@@ -0,0 +1,65 @@
+// image.go - Draw the grid of results of a finished game as a PNG, for
+// --image.  Like the share format, it shows only the colors, not the
+// letters.
+
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+)
+
+// The size of each square, and of the gap around it, in pixels.
+const (
+	IMAGE_SQUARE = 60
+	IMAGE_GAP    = 6
+)
+
+// The colors Wordle uses for each response character.
+var colorForResponse = map[string]color.RGBA{
+	"y": {0x6a, 0xaa, 0x64, 0xff},
+	"p": {0xc9, 0xb4, 0x58, 0xff},
+	"n": {0x78, 0x7c, 0x7e, 0xff},
+}
+
+var imageBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+
+// Return a picture of responses, one row of squares for each.
+func drawGrid(responses []string) *image.RGBA {
+	width := LETTERS_IN_WORD*(IMAGE_SQUARE+IMAGE_GAP) + IMAGE_GAP
+	height := len(responses)*(IMAGE_SQUARE+IMAGE_GAP) + IMAGE_GAP
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.SetRGBA(x, y, imageBackground)
+		}
+	}
+	for row, response := range responses {
+		top := IMAGE_GAP + row*(IMAGE_SQUARE+IMAGE_GAP)
+		for j := 0; j < len(response); j++ {
+			left := IMAGE_GAP + j*(IMAGE_SQUARE+IMAGE_GAP)
+			squareColor := colorForResponse[response[j:j+1]]
+			for x := left; x < left+IMAGE_SQUARE; x++ {
+				for y := top; y < top+IMAGE_SQUARE; y++ {
+					img.SetRGBA(x, y, squareColor)
+				}
+			}
+		}
+	}
+	return img
+}
+
+// Write a PNG picture of responses to path.
+func writeGridImage(path string, responses []string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, drawGrid(responses)); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,148 @@
+// input.go - Reading lines typed by the user, with an optional time limit.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Lines read from MyScanner by a separate goroutine, so that a read can
+// give up after a time limit.  nil until startInputReader is called.
+var inputLines chan string
+
+// Start reading input in a goroutine.  This is only needed when reads
+// must be able to time out, as in --challenge mode.  Only one reader is
+// started, however many games are played, so that no line goes to a
+// reader no one is listening to.
+func startInputReader() {
+	if inputLines != nil {
+		return
+	}
+	inputLines = make(chan string)
+	go func() {
+		for MyScanner.Scan() {
+			inputLines <- strings.TrimSpace(MyScanner.Text())
+		}
+		close(inputLines)
+	}()
+}
+
+// If true, print each line of input after reading it, as it didn't come
+// from the terminal, which would have shown it already.
+var echoInput = false
+
+// Read a line of input.  ok is false at the end of input.  Surrounding
+// white space is removed, including the carriage return at the end of
+// each line of a file written on Windows.
+func readLine() (line string, ok bool) {
+	if inputLines != nil {
+		line, ok = <-inputLines
+		return line, ok
+	}
+	if !MyScanner.Scan() {
+		return "", false
+	}
+	line = strings.TrimSpace(MyScanner.Text())
+	if echoInput {
+		fmt.Println(line)
+	}
+	return line, true
+}
+
+// Read a line of input, giving up at deadline.  A zero deadline means
+// wait as long as it takes.  timedOut is true if the deadline passed;
+// ok is false at the end of input.
+func readLineBefore(deadline time.Time) (line string, ok bool, timedOut bool) {
+	if deadline.IsZero() {
+		line, ok = readLine()
+		return line, ok, false
+	}
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case line, ok = <-inputLines:
+		return line, ok, false
+	case <-timer.C:
+		return "", true, true
+	}
+}
+
+// While echo is off, the function that turns it back on, so that the
+// interrupt handler can put the terminal back before exiting.
+var echoRestore struct {
+	sync.Mutex
+	restore func()
+}
+
+// Read a line of input without echoing it, if standard input is a
+// terminal.  hidden is false if echo could not be turned off, for
+// example because input is not a terminal.
+func readHiddenLine() (line string, ok bool, hidden bool) {
+	restore, err := disableEcho(int(os.Stdin.Fd()))
+	if err == nil {
+		echoRestore.Lock()
+		echoRestore.restore = restore
+		echoRestore.Unlock()
+		defer restoreEcho()
+	}
+	line, ok = readLine()
+	return line, ok, err == nil
+}
+
+// Turn echo back on, if readHiddenLine turned it off.
+func restoreEcho() {
+	echoRestore.Lock()
+	defer echoRestore.Unlock()
+	if echoRestore.restore != nil {
+		echoRestore.restore()
+		echoRestore.restore = nil
+	}
+}
+
+// The width of the terminal to assume when it can't be found out.
+const DEFAULT_WIDTH = 80
+
+// Return the width of the terminal in columns: from the terminal if
+// standard output is one, otherwise from $COLUMNS, otherwise DEFAULT_WIDTH.
+func terminalWidth() int {
+	if width, err := terminalColumns(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return DEFAULT_WIDTH
+}
+
+// Ask for the secret word for --run mode without echoing it, so that one
+// player can type it in while the other looks away.  Keep asking until
+// the word has the right length.  A word not in the word list is played
+// with a warning, as for --word, unless strict is set, when it is asked
+// for again.  ok is false at the end of input.
+func readSecret(strict bool) (word string, ok bool) {
+	for {
+		fmt.Print("Enter secret word (hidden): ")
+		line, ok, _ := readHiddenLine()
+		fmt.Println()
+		if !ok {
+			return "", false
+		}
+		word = strings.ToLower(strings.TrimSpace(line))
+		if utf8.RuneCountInString(word) != LETTERS_IN_WORD {
+			fmt.Printf("The word must have %v letters\n", LETTERS_IN_WORD)
+		} else if !isKnownWord(word) && strict {
+			fmt.Println("That word is not in the word list, as --strict-secret requires")
+		} else {
+			if !isKnownWord(word) {
+				fmt.Println("Warning: that word is not in the word list; playing it anyway")
+			}
+			return word, true
+		}
+	}
+}
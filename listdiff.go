@@ -0,0 +1,81 @@
+// listdiff.go - Compare two word lists, for --diff-lists, to see what
+// merging or updating a list would change.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Return the words of the list called name, a built-in list or a file,
+// lowercased.  Unlike selectWordList, it keeps words of any length, and
+// leaves AllWords alone.
+func readListWords(name string) (StringSet, error) {
+	var words []string
+	if getList, present := wordLists[name]; present {
+		words = getList()
+	} else {
+		var err error
+		words, _, err = readWordListFile(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	set := make(StringSet)
+	for _, word := range words {
+		set.Add(strings.ToLower(word))
+	}
+	return set, nil
+}
+
+// Return the words in set that are not in other, in alphabetical order.
+func wordsNotIn(set StringSet, other StringSet) []string {
+	var words []string
+	for word := range set {
+		if !other.Contains(word) {
+			words = append(words, word)
+		}
+	}
+	sort.Strings(words)
+	return words
+}
+
+// Print how many words are only in name1, only in name2, and in both,
+// and list the words only in one of them.  If verbose is set, list the
+// shared words too.
+func diffLists(name1 string, name2 string, verbose bool) error {
+	words1, err := readListWords(name1)
+	if err != nil {
+		return fmt.Errorf("%v: %v", name1, err)
+	}
+	words2, err := readListWords(name2)
+	if err != nil {
+		return fmt.Errorf("%v: %v", name2, err)
+	}
+	only1 := wordsNotIn(words1, words2)
+	only2 := wordsNotIn(words2, words1)
+	fmt.Printf("%v has %v words, %v has %v; %v are in both\n",
+		name1, len(words1), name2, len(words2), len(words1)-len(only1))
+	for _, side := range []struct {
+		name  string
+		words []string
+	}{{name1, only1}, {name2, only2}} {
+		fmt.Printf("Only in %v: %v\n", side.name, len(side.words))
+		if len(side.words) > 0 {
+			fmt.Println("  " + strings.Join(side.words, " "))
+		}
+	}
+	if verbose {
+		var shared []string
+		for word := range words1 {
+			if words2.Contains(word) {
+				shared = append(shared, word)
+			}
+		}
+		sort.Strings(shared)
+		fmt.Println("In both: " + strings.Join(shared, " "))
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// locked.go - Start a run-mode game with some letters already known in
+// place, given with --locked, as when carrying on with a game played on
+// paper.
+
+package main
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Report whether letter marks a position that isn't locked in a --locked
+// pattern.  As with --pattern, _ and . both do.
+func isUnlocked(letter rune) bool {
+	return letter == '_' || letter == '.'
+}
+
+// Report whether word has the letters of the locked pattern in place.
+func fitsLocked(word string, locked string) bool {
+	if len(locked) == 0 {
+		return true
+	}
+	if utf8.RuneCountInString(word) != LETTERS_IN_WORD {
+		return false
+	}
+	lockedLetters := []rune(locked)
+	for ipos, letter := range []rune(word) {
+		if !isUnlocked(lockedLetters[ipos]) && letter != lockedLetters[ipos] {
+			return false
+		}
+	}
+	return true
+}
+
+// Return guess with each _ in a locked position replaced by the locked
+// letter, so that the player need only type the rest.
+func fillLocked(guess string, locked string) string {
+	if len(locked) == 0 || utf8.RuneCountInString(guess) != LETTERS_IN_WORD {
+		return guess
+	}
+	letters := []rune(guess)
+	for ipos, lockedLetter := range []rune(locked) {
+		if letters[ipos] == '_' && !isUnlocked(lockedLetter) {
+			letters[ipos] = lockedLetter
+		}
+	}
+	return string(letters)
+}
+
+// Return a message saying why guess changes a locked letter, or "" if
+// it keeps them all.
+func lockedConflict(guess string, locked string) string {
+	if fitsLocked(guess, locked) {
+		return ""
+	}
+	lockedLetters := []rune(locked)
+	for ipos, letter := range []rune(guess) {
+		if !isUnlocked(lockedLetters[ipos]) && letter != lockedLetters[ipos] {
+			return fmt.Sprintf("position %v is locked to %c", ipos+1, lockedLetters[ipos])
+		}
+	}
+	return ""
+}
+
+// Return which positions the locked pattern gives, for the letters shown
+// to the player as known.
+func lockedPositions(locked string) []bool {
+	known := make([]bool, LETTERS_IN_WORD)
+	for ipos, letter := range []rune(locked) {
+		known[ipos] = !isUnlocked(letter)
+	}
+	return known
+}
@@ -0,0 +1,132 @@
+// opener.go - The solver's first guess.  It can be given with
+// --first-guess, which can also fix the guesses after it; otherwise, if
+// --analyze has been run for the word list in use, the best opener it
+// found is read from a cache file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// The solver's first guesses, whatever the responses to them, before the
+// strategy takes over.  Empty to let the strategy choose from the start.
+var fixedGuesses []string
+
+// If true, the solver's first VOWEL_PROBES guesses are the words that try
+// the most vowels not tried yet, as many players like to start, before
+// the strategy takes over.  Set by --vowels-first.
+var vowelsFirst = false
+
+const VOWEL_PROBES = 2
+
+const VOWELS = "aeiou"
+
+// Return the word that tries the most distinct vowels not in any earlier
+// guess, preferring the one that tries the most new letters of any kind.
+// Return "" if no word tries a new vowel.
+func (solver *Solver) vowelProbe() string {
+	tried := make(StringSet)
+	for _, entry := range solver.history {
+		for _, letter := range entry.guess {
+			tried.Add(string(letter))
+		}
+	}
+	best := ""
+	bestVowels, bestLetters := 0, 0
+	for _, word := range AllWords {
+		newLetters := make(StringSet)
+		numVowels := 0
+		for _, letter := range word {
+			if tried.Contains(string(letter)) || newLetters.Contains(string(letter)) {
+				continue
+			}
+			newLetters.Add(string(letter))
+			if strings.ContainsRune(VOWELS, letter) {
+				numVowels++
+			}
+		}
+		if numVowels > bestVowels || (numVowels == bestVowels && numVowels > 0 && len(newLetters) > bestLetters) {
+			best = word
+			bestVowels = numVowels
+			bestLetters = len(newLetters)
+		}
+	}
+	return best
+}
+
+// Return a string that identifies the contents of the word list in use,
+// so that cached results for one list aren't used for another.  The
+// order of the words (see --order) doesn't matter.
+func wordListHash() string {
+	words := make([]string, len(AllWords))
+	copy(words, AllWords)
+	sort.Strings(words)
+	hash := fnv.New64a()
+	hash.Write([]byte(strings.Join(words, "\n")))
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
+
+// Return the name of the file caching the best opener for each word list.
+func openerCachePath() (string, error) {
+	dir, err := cacheDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "openers.json"), nil
+}
+
+// Read the opener cache, a map from wordListHash() to the best opener.
+// A missing or unreadable cache is treated as empty.
+func readOpenerCache() map[string]string {
+	cache := make(map[string]string)
+	path, err := openerCachePath()
+	if err != nil {
+		return cache
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	if json.Unmarshal(data, &cache) != nil {
+		return make(map[string]string)
+	}
+	return cache
+}
+
+func writeOpenerCache(cache map[string]string) error {
+	path, err := openerCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Return the best opener cached for the word list in use, or "".
+func cachedOpener() string {
+	return readOpenerCache()[wordListHash()]
+}
+
+// Work out the most informative first guess for the word list in use,
+// and cache it so the solver starts with it from now on.
+func analyzeOpener() error {
+	fmt.Println("Working out the best first guess; this takes a while...")
+	best := scoreGuesses("entropy", AllWords, true)[0]
+	fmt.Printf("The best first guess is %v (%.3f bits)\n", best.word, best.score)
+	cache := readOpenerCache()
+	cache[wordListHash()] = best.word
+	return writeOpenerCache(cache)
+}
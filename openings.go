@@ -0,0 +1,108 @@
+// openings.go - A table of the best second guess for each response to
+// the solver's first guess.  Working these out takes a while, so they
+// are precomputed with --make-openings and loaded with --openings.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Map from openingKey(first guess, response) to the best second guess.
+var openings = make(map[string]string)
+
+func openingKey(firstGuess string, response string) string {
+	return firstGuess + " " + response
+}
+
+// Load the openings table from path.  Each non-blank line holds a first
+// guess, the response to it, and the second guess to make.  Lines
+// starting with # are comments.
+func loadOpenings(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return fmt.Errorf("%v line %v: expected first-guess response second-guess", path, lineNum)
+		}
+		for _, field := range fields {
+			if utf8.RuneCountInString(field) != LETTERS_IN_WORD {
+				return fmt.Errorf("%v line %v: %v is not %v letters long", path, lineNum, field, LETTERS_IN_WORD)
+			}
+		}
+		openings[openingKey(fields[0], fields[1])] = fields[2]
+	}
+	return scanner.Err()
+}
+
+// Return the word that is expected to leave the fewest of candidates.
+// Any word in the list may be chosen, but when there is a tie we prefer
+// a word that could itself be the answer.
+func bestProbe(candidates []string) string {
+	if len(candidates) <= 2 {
+		return candidates[0]
+	}
+	isCandidate := make(StringSet)
+	for _, candidate := range candidates {
+		isCandidate.Add(candidate)
+	}
+	bestWord := ""
+	bestScore := 0.0
+	for _, word := range AllWords {
+		score := expectedRemaining(responseBuckets(word, candidates), len(candidates))
+		if len(bestWord) == 0 || score < bestScore ||
+			(score == bestScore && isCandidate.Contains(word) && !isCandidate.Contains(bestWord)) {
+			bestWord = word
+			bestScore = score
+		}
+	}
+	return bestWord
+}
+
+// Work out the openings table for the solver's first guess, and write it
+// to path.
+func makeOpenings(path string) error {
+	firstGuess := NewSolver().chooseGuess()
+	groups := make(map[string][]string)
+	for _, word := range AllWords {
+		response := evaluateGuess(firstGuess, word)
+		groups[response] = append(groups[response], word)
+	}
+	var responses []string
+	for response := range groups {
+		if response != "yyyyy" {
+			responses = append(responses, response)
+		}
+	}
+	sort.Strings(responses)
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	fmt.Fprintf(writer, "# Best second guesses after %v\n", firstGuess)
+	for _, response := range responses {
+		fmt.Fprintf(writer, "%v %v %v\n", firstGuess, response, bestProbe(groups[response]))
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
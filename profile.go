@@ -0,0 +1,42 @@
+// profile.go - Write CPU and memory profiles for use with go tool pprof.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// Start writing a CPU profile to path.  Return a function that stops the
+// profile and closes the file.
+func startCPUProfile(path string) (func(), error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := pprof.StartCPUProfile(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return func() {
+		pprof.StopCPUProfile()
+		file.Close()
+	}, nil
+}
+
+// Write a heap profile to path.
+func writeMemProfile(path string) {
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Println("Cannot write memory profile: " + err.Error())
+		return
+	}
+	defer file.Close()
+	// Get up-to-date statistics.
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(file); err != nil {
+		fmt.Println("Cannot write memory profile: " + err.Error())
+	}
+}
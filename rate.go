@@ -0,0 +1,47 @@
+// rate.go - Rate how hard a word is for the solver, for --rate.
+
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Well-known first guesses.  --rate reports how many words each leaves
+// indistinguishable from the word being rated.
+var COMMON_OPENERS = []string{"crane", "slate", "raise", "trace", "stare", "audio"}
+
+// Report how many guesses the solver needs to find word, and how many
+// words still look the same as word after each common opener.  The
+// rating is the average, over the openers, of log2 of that number: the
+// bits of uncertainty an opener leaves.  0 means an opener alone always
+// pins the word down; higher is harder.  Return an error if word is not
+// in the word list.
+func rateWord(word string) error {
+	if !isKnownWord(word) {
+		return fmt.Errorf("%v is not in the word list", word)
+	}
+	numGuesses, solved := solveTarget(word, false)
+	if solved {
+		fmt.Printf("The solver (strategy %v) finds %v in %v guesses\n", activeStrategy, word, numGuesses)
+	} else {
+		fmt.Printf("The solver (strategy %v) fails to find %v\n", activeStrategy, word)
+	}
+	totalBits := 0.0
+	numOpeners := 0
+	for _, opener := range COMMON_OPENERS {
+		// Openers not in the list being used are skipped.
+		if !isKnownWord(opener) {
+			continue
+		}
+		response := evaluateGuess(opener, word)
+		numAlike := responseBuckets(opener, AllWords)[response]
+		fmt.Printf("After %v (%v): %v words fit, including %v\n", opener, response, numAlike, word)
+		totalBits += math.Log2(float64(numAlike))
+		numOpeners++
+	}
+	if numOpeners > 0 {
+		fmt.Printf("Rating: %.1f\n", totalBits/float64(numOpeners))
+	}
+	return nil
+}
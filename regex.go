@@ -0,0 +1,80 @@
+// regex.go - Show the solver's clues as a regular expression, for pasting
+// into other tools.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// The characters that have a special meaning inside [...] in a regular
+// expression.  --alphabet can include them.
+const CLASS_SPECIAL = `\]^-[`
+
+// Return letters escaped for use inside [...].
+func escapeClass(letters string) string {
+	escaped := ""
+	for _, letter := range letters {
+		if strings.ContainsRune(CLASS_SPECIAL, letter) {
+			escaped += `\`
+		}
+		escaped += string(letter)
+	}
+	return escaped
+}
+
+// Return a regular expression matching the words that fit validLetters,
+// like "^[abc][^xy].r.$".  Each position is a single letter if it is
+// known, . if any letter is possible, and otherwise whichever of a set
+// or a negated set is shorter.
+func (solver *Solver) regex() string {
+	regex := "^"
+	for _, valid := range solver.validLetters {
+		allowed := ""
+		excluded := ""
+		numAllowed := 0
+		for _, letter := range alphabet {
+			if valid.Contains(string(letter)) {
+				allowed += string(letter)
+				numAllowed++
+			} else {
+				excluded += string(letter)
+			}
+		}
+		if numAllowed == 1 {
+			regex += regexp.QuoteMeta(allowed)
+		} else if len(excluded) == 0 {
+			regex += "."
+		} else if len(excluded) < len(allowed) {
+			regex += "[^" + escapeClass(excluded) + "]"
+		} else {
+			regex += "[" + escapeClass(allowed) + "]"
+		}
+	}
+	return regex + "$"
+}
+
+// Return the letters known to be in the word, each repeated as many times
+// as it must appear, like "e r s s".
+func (solver *Solver) formatRequiredLetters() string {
+	var letters []string
+	for letter, count := range solver.requiredLetters {
+		for i := 0; i < count; i++ {
+			letters = append(letters, letter)
+		}
+	}
+	sort.Strings(letters)
+	return strings.Join(letters, " ")
+}
+
+// Print the clues as a regular expression and the letters it doesn't
+// capture: those known to be in the word somewhere.
+func (solver *Solver) printRegex() {
+	fmt.Println("Regex: " + solver.regex())
+	if len(solver.requiredLetters) > 0 {
+		fmt.Println("Required letters: " + solver.formatRequiredLetters())
+	}
+}
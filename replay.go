@@ -0,0 +1,70 @@
+// replay.go - Re-score the guesses from a finished game against a
+// different word, to see how the responses would have differed.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// One guess from a transcript, plus the response it got, if known.
+type TranscriptEntry struct {
+	guess    string
+	response string
+}
+
+// Read a transcript file.  Each non-blank line holds a guess, optionally
+// followed by whitespace and the response (y/p/n) it got.  Lines starting
+// with # are comments.
+func readTranscript(path string) ([]TranscriptEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []TranscriptEntry
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) > 2 {
+			return nil, fmt.Errorf("%v line %v: expected a guess and optional response", path, lineNum)
+		}
+		entry := TranscriptEntry{guess: fields[0]}
+		if len(fields) == 2 {
+			entry.response = fields[1]
+		}
+		if err := checkGuessLength(entry.guess); err != nil {
+			return nil, fmt.Errorf("%v line %v: %w", path, lineNum, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+func replayTranscript(path string, word string) error {
+	entries, err := readTranscript(path)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		newResponse := evaluateGuess(entry.guess, word)
+		if len(entry.response) == 0 {
+			fmt.Printf("%v  %v\n", entry.guess, newResponse)
+		} else {
+			marker := ""
+			if newResponse != entry.response {
+				marker = " *"
+			}
+			fmt.Printf("%v  %v -> %v%v\n", entry.guess, entry.response, newResponse, marker)
+		}
+	}
+	return nil
+}
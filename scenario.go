@@ -0,0 +1,74 @@
+// scenario.go - Play a run-mode game from a file that gives the word and
+// the player's input, for --scenario.  The file looks like:
+//
+//	# Comments and blank lines are ignored.
+//	secret crane
+//	slate
+//	crane
+//
+// Each line after the secret is read as if the player had typed it.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Read a scenario file, returning the secret word and the lines of input.
+func readScenario(path string) (string, []string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", nil, err
+	}
+	defer file.Close()
+
+	secret := ""
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if fields[0] != "secret" {
+			if len(secret) == 0 {
+				return "", nil, fmt.Errorf("%v line %v: the secret must come before the guesses", path, lineNum)
+			}
+			lines = append(lines, line)
+		} else if len(secret) > 0 {
+			return "", nil, fmt.Errorf("%v line %v: there is more than one secret", path, lineNum)
+		} else if len(fields) != 2 || utf8.RuneCountInString(fields[1]) != LETTERS_IN_WORD {
+			return "", nil, fmt.Errorf("%v line %v: expected secret and a %v-letter word", path, lineNum, LETTERS_IN_WORD)
+		} else {
+			secret = strings.ToLower(fields[1])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", nil, err
+	}
+	if len(secret) == 0 {
+		return "", nil, fmt.Errorf("%v has no secret line", path)
+	}
+	if len(lines) == 0 {
+		return "", nil, fmt.Errorf("%v has no guesses", path)
+	}
+	return secret, lines, nil
+}
+
+// Set things up to play the scenario in path: the word, and input that
+// comes from the file rather than the user.
+func loadScenario(path string, settings *Settings) error {
+	secret, lines, err := readScenario(path)
+	if err != nil {
+		return err
+	}
+	settings.word = secret
+	echoInput = true
+	MyScanner = *bufio.NewScanner(strings.NewReader(strings.Join(lines, "\n")))
+	return nil
+}
@@ -0,0 +1,83 @@
+// scorecache.go - Keep the scores of every word as a first guess on
+// disk, since scoring the whole word list against itself is slow and
+// always gives the same answer for the same list.
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// The directory for wordg's cache files.  "" means the user's cache
+// directory (such as ~/.cache/wordg).  Set by --cache-dir.
+var cacheDir = ""
+
+// Return the directory for wordg's cache files.
+func cacheDirectory() (string, error) {
+	if len(cacheDir) > 0 {
+		return cacheDir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "wordg"), nil
+}
+
+// The scores of every word in a word list as a first guess, under one
+// strategy.
+type ScoreCache struct {
+	Strategy string
+	Scores   map[string]float64
+}
+
+// Return the file caching the first-guess scores for strategy and the
+// word list in use.  Naming it by the list's hash means a changed list
+// never uses the scores of the old one.
+func scoreCachePath(strategy string) (string, error) {
+	dir, err := cacheDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "scores-"+strategy+"-"+wordListHash()+".json"), nil
+}
+
+// Return the score of each word in the list as a first guess under
+// strategy, from the cache if it holds them, otherwise by working them
+// out and caching them.  A cache that can't be read, or is missing any
+// word, is treated as corrupt and replaced.  Failing to write the cache
+// only means the scores are worked out again next time.
+func openingScores(strategy string) map[string]float64 {
+	path, err := scoreCachePath(strategy)
+	if err == nil {
+		if data, err := os.ReadFile(path); err == nil {
+			var cache ScoreCache
+			if json.Unmarshal(data, &cache) == nil && cache.Strategy == strategy && hasScoreForEvery(cache.Scores) {
+				return cache.Scores
+			}
+		}
+	}
+	scores := make(map[string]float64)
+	for _, guess := range AllWords {
+		scores[guess] = scoreGuess(strategy, guess, AllWords)
+	}
+	if len(path) > 0 {
+		if data, err := json.Marshal(ScoreCache{Strategy: strategy, Scores: scores}); err == nil {
+			if os.MkdirAll(filepath.Dir(path), 0755) == nil {
+				os.WriteFile(path, data, 0644)
+			}
+		}
+	}
+	return scores
+}
+
+func hasScoreForEvery(scores map[string]float64) bool {
+	for _, word := range AllWords {
+		if _, present := scores[word]; !present {
+			return false
+		}
+	}
+	return true
+}
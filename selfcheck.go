@@ -0,0 +1,51 @@
+// selfcheck.go - Check that the solver is consistent with evaluateGuess:
+// the clues it derives from each response must never rule out the word
+// that produced the response.
+
+package main
+
+import "fmt"
+
+// Solve target, checking each step.  Return a description of the first
+// problem found, or "" if there was none.
+func checkTarget(target string) string {
+	solver := NewSolver()
+	for numGuesses := 1; ; numGuesses++ {
+		myGuess := solver.chooseGuess()
+		if len(myGuess) == 0 {
+			return "the solver ran out of words without finding it"
+		}
+		response := evaluateGuess(myGuess, target)
+		solver.processResponse(myGuess, response)
+		if solver.solved {
+			if myGuess != target {
+				return fmt.Sprintf("the solver stopped on %v", myGuess)
+			}
+			if numGuesses > MAX_GUESSES {
+				return fmt.Sprintf("it took %v guesses", numGuesses)
+			}
+			return ""
+		}
+		if !solver.matchesClues(target) {
+			return fmt.Sprintf("after guess %v, %v (%v), the clues rule it out: %v",
+				numGuesses, myGuess, response, solver.explainMismatch(target))
+		}
+	}
+}
+
+// Check the solver against sampleSize words chosen at random, or every
+// word if sampleSize is at least the size of the word list.  Return true
+// if no problems were found.
+func selfCheck(sampleSize int) bool {
+	targets := sampleWords(AllWords, sampleSize)
+	numProblems := 0
+	for _, target := range targets {
+		problem := checkTarget(target)
+		if len(problem) > 0 {
+			fmt.Printf("%v: %v\n", target, problem)
+			numProblems++
+		}
+	}
+	fmt.Printf("Checked %v words; found problems with %v\n", len(targets), numProblems)
+	return numProblems == 0
+}
@@ -0,0 +1,78 @@
+// selftest.go - A small built-in battery of known cases, run with --test,
+// to check that a built binary scores guesses and applies responses
+// correctly.  Unlike --selfcheck, the cases don't depend on the word list.
+// The full set of cases is in the package's tests.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Run the battery, printing each failure.  Return true if every case
+// passed.
+func runSelfTests() bool {
+	numCases := 0
+	numFailed := 0
+	fail := func(format string, args ...interface{}) {
+		fmt.Printf("FAIL: "+format+"\n", args...)
+		numFailed++
+	}
+	// A guess, the word it's scored against, and the response Wordle gives.
+	scoring := []struct {
+		guess    string
+		word     string
+		response string
+	}{
+		{"crane", "crane", "yyyyy"},
+		{"slate", "crane", "nnyny"},
+		{"speed", "abide", "nnpnp"},
+		{"lolly", "hello", "npyyn"},
+		{"soñar", "señor", "ypyny"},
+	}
+	for _, c := range scoring {
+		numCases++
+		if got := evaluateGuess(c.guess, c.word); got != c.response {
+			fail("%v against %v gave %v, want %v", c.guess, c.word, got, c.response)
+		}
+	}
+	// Guesses made against target.  After each response, target must
+	// still fit the clues, and every word in ruledOut must not.
+	solving := []struct {
+		target   string
+		guesses  []string
+		ruledOut []string
+	}{
+		{"crane", []string{"slate"}, []string{"slate", "stale", "shine"}},
+		{"there", []string{"erase", "eerie"}, []string{"geese", "erase"}},
+		{"essay", []string{"esses"}, []string{"esses", "esssy", "essae"}},
+	}
+	for _, c := range solving {
+		numCases++
+		solver := NewSolver()
+		var steps []string
+		for _, guess := range c.guesses {
+			response := evaluateGuess(guess, c.target)
+			steps = append(steps, guess+" "+response)
+			if _, err := solver.processResponse(guess, response); err != nil {
+				fail("after %v, the response was rejected: %v", strings.Join(steps, ", "), err)
+			}
+			if !solver.matchesClues(c.target) {
+				fail("after %v, %v is ruled out: %v", strings.Join(steps, ", "), c.target,
+					solver.explainMismatch(c.target))
+			}
+		}
+		for _, word := range c.ruledOut {
+			if solver.matchesClues(word) {
+				fail("after %v, %v still fits the clues", strings.Join(steps, ", "), word)
+			}
+		}
+	}
+	if numFailed == 0 {
+		fmt.Printf("All %v cases passed\n", numCases)
+	} else {
+		fmt.Printf("%v checks failed in %v cases\n", numFailed, numCases)
+	}
+	return numFailed == 0
+}
@@ -0,0 +1,147 @@
+// session.go - Save the state of a guess-mode session to a file, so it
+// can be resumed later with --resume.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// The format of saved sessions.  Increase this when the format changes
+// in a way older versions of wordg can't read.
+const SESSION_VERSION = 1
+
+type SavedGuess struct {
+	Guess    string
+	Response string
+}
+
+type SavedClue struct {
+	AfterGuesses int
+	Letter       string
+	Count        int
+}
+
+type SavedBoard struct {
+	// For each position, the letters that could still be there, in
+	// alphabetical order.
+	ValidLetters    []string
+	RequiredLetters map[string]int
+	MaxLetters      map[string]int
+	History         []SavedGuess
+	// The clues from the no and yes commands.  Sessions saved before
+	// they were recorded don't have them.
+	Manual []SavedClue `json:",omitempty"`
+	Solved bool
+	Answer string
+}
+
+type SavedSession struct {
+	Version int
+	Boards  []SavedBoard
+}
+
+func (solver *Solver) save() SavedBoard {
+	// Copy requiredLetters and maxLetters, so that the saved board doesn't
+	// change as the solver does.
+	board := SavedBoard{
+		RequiredLetters: make(map[string]int),
+		MaxLetters:      make(map[string]int),
+		Solved:          solver.solved,
+		Answer:          solver.answer,
+	}
+	for letter, count := range solver.requiredLetters {
+		board.RequiredLetters[letter] = count
+	}
+	for letter, count := range solver.maxLetters {
+		board.MaxLetters[letter] = count
+	}
+	for _, letters := range solver.validLetters {
+		var list []string
+		for letter := range letters {
+			list = append(list, letter)
+		}
+		sort.Strings(list)
+		board.ValidLetters = append(board.ValidLetters, strings.Join(list, ""))
+	}
+	for _, entry := range solver.history {
+		board.History = append(board.History, SavedGuess{Guess: entry.guess, Response: entry.response})
+	}
+	for _, clue := range solver.manual {
+		board.Manual = append(board.Manual, SavedClue{AfterGuesses: clue.afterGuesses, Letter: clue.letter, Count: clue.count})
+	}
+	return board
+}
+
+func restoreSolver(board SavedBoard) (*Solver, error) {
+	if len(board.ValidLetters) != LETTERS_IN_WORD {
+		return nil, fmt.Errorf("saved board has %v positions, not %v", len(board.ValidLetters), LETTERS_IN_WORD)
+	}
+	solver := &Solver{requiredLetters: board.RequiredLetters, maxLetters: board.MaxLetters,
+		solved: board.Solved, answer: board.Answer}
+	if solver.requiredLetters == nil {
+		solver.requiredLetters = make(map[string]int)
+	}
+	// Sessions saved before maxLetters was added don't have it.
+	if solver.maxLetters == nil {
+		solver.maxLetters = make(map[string]int)
+	}
+	for ipos, letters := range board.ValidLetters {
+		solver.validLetters[ipos] = make(StringSet)
+		for _, letter := range letters {
+			solver.validLetters[ipos].Add(string(letter))
+		}
+	}
+	for _, entry := range board.History {
+		solver.history = append(solver.history, TranscriptEntry{guess: entry.Guess, response: entry.Response})
+	}
+	for _, clue := range board.Manual {
+		solver.manual = append(solver.manual, ManualClue{afterGuesses: clue.AfterGuesses, letter: clue.Letter, count: clue.Count})
+	}
+	return solver, nil
+}
+
+// Write the state of boards to path.
+func saveSession(path string, boards []*Solver) error {
+	session := SavedSession{Version: SESSION_VERSION}
+	for _, solver := range boards {
+		session.Boards = append(session.Boards, solver.save())
+	}
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Read boards saved by saveSession.
+func loadSession(path string) ([]*Solver, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var session SavedSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("%v is not a saved session: %v", path, err)
+	}
+	if session.Version != SESSION_VERSION {
+		return nil, fmt.Errorf("%v was saved in format %v, but this version of wordg reads format %v",
+			path, session.Version, SESSION_VERSION)
+	}
+	if len(session.Boards) == 0 {
+		return nil, fmt.Errorf("%v has no boards", path)
+	}
+	var boards []*Solver
+	for _, board := range session.Boards {
+		solver, err := restoreSolver(board)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v", path, err)
+		}
+		boards = append(boards, solver)
+	}
+	return boards, nil
+}
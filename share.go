@@ -0,0 +1,111 @@
+// share.go - Format a finished game the way the official Wordle does
+// when you share it, like:
+//
+//	Wordle 1,234 4/6
+//
+//	⬛🟨⬛⬛🟨
+//	...
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The date of Wordle puzzle 0, which is how puzzle numbers are worked out.
+const DEFAULT_EPOCH = "2021-06-19"
+
+const DATE_LAYOUT = "2006-01-02"
+
+var emojiForResponse = map[string]string{"y": "🟩", "p": "🟨", "n": "⬛"}
+
+// Return response as a row of colored squares.
+func emojiRow(response string) string {
+	row := ""
+	for j := 0; j < len(response); j++ {
+		row += emojiForResponse[response[j:j+1]]
+	}
+	return row
+}
+
+// Return the number of the Wordle puzzle for date, counting from epoch.
+// Both are in YYYY-MM-DD form; an empty date means today.
+func puzzleNumber(date string, epoch string) (int, error) {
+	epochTime, err := time.Parse(DATE_LAYOUT, epoch)
+	if err != nil {
+		return 0, fmt.Errorf("bad epoch date %v", epoch)
+	}
+	var dateTime time.Time
+	if len(date) == 0 {
+		now := time.Now()
+		dateTime = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	} else if dateTime, err = time.Parse(DATE_LAYOUT, date); err != nil {
+		return 0, fmt.Errorf("bad date %v", date)
+	}
+	return int(dateTime.Sub(epochTime).Hours() / 24), nil
+}
+
+// Return n with commas between groups of three digits, as in 1,234.
+func formatWithCommas(n int) string {
+	digits := fmt.Sprint(n)
+	if n < 0 {
+		return "-" + formatWithCommas(-n)
+	}
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return digits
+}
+
+// Return the share block for a game with the given responses.  solved
+// says whether the last response found the word; numGuesses is the
+// number of guesses that counted, which can be more than the responses
+// when a guess counts without getting one, as a --challenge timeout or a
+// --free-invalid penalty does; maxGuesses is the number that were allowed.
+func formatShare(number int, responses []string, solved bool, numGuesses int, maxGuesses int) string {
+	score := "X"
+	if solved {
+		score = fmt.Sprint(numGuesses)
+	}
+	lines := []string{fmt.Sprintf("Wordle %v %v/%v", formatWithCommas(number), score, maxGuesses), ""}
+	for _, response := range responses {
+		lines = append(lines, emojiRow(response))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Print only the share block for the game in which settings.guesses were
+// guessed, in order, against settings.word, for --make-share.  Return an
+// error, printing nothing, if a guess isn't valid or the game couldn't
+// have gone that way.
+func makeShareBlock(settings Settings) error {
+	maxGuesses := settings.maxGuesses
+	if maxGuesses == 0 {
+		maxGuesses = MAX_GUESSES
+	}
+	if len(settings.guesses) > maxGuesses {
+		return fmt.Errorf("%v guesses is more than the %v allowed", len(settings.guesses), maxGuesses)
+	}
+	var responses []string
+	solved := false
+	for _, guess := range settings.guesses {
+		if solved {
+			return fmt.Errorf("%v comes after the word was found", guess)
+		}
+		if err := checkGuessLength(guess); err != nil {
+			return err
+		}
+		if err := checkKnownWord(guess); err != nil && settings.strict {
+			return err
+		}
+		response := evaluateGuess(guess, settings.word)
+		responses = append(responses, response)
+		solved = response == strings.Repeat("y", LETTERS_IN_WORD)
+	}
+	// This was checked when the command line was parsed.
+	number, _ := puzzleNumber(settings.date, settings.epoch)
+	fmt.Println(formatShare(number, responses, solved, len(responses), maxGuesses))
+	return nil
+}
@@ -0,0 +1,50 @@
+// speech.go - Describe each result in words and pipe it to a text to
+// speech command, for --tts-command, so that players who can't see the
+// screen can hear how each guess did.
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// How each response character is read out.  - is the mark --blind uses
+// for anything that isn't green.
+var spokenResponses = map[string]string{
+	"y": "correct",
+	"p": "in the word, elsewhere",
+	"n": "not in the word",
+	"-": "not correct",
+}
+
+// Return a description of the result of guess that reads well aloud,
+// like "c correct. r in the word, elsewhere. a not in the word. ...".
+func describeResult(guess string, response string) string {
+	if response == strings.Repeat("y", LETTERS_IN_WORD) {
+		return guess + ": every letter correct. You found the word."
+	}
+	var parts []string
+	for j, letter := range []rune(guess) {
+		parts = append(parts, string(letter)+" "+spokenResponses[response[j:j+1]]+".")
+	}
+	return guess + ": " + strings.Join(parts, " ")
+}
+
+// Run command, split into words, with text as its standard input.
+func speak(command string, text string) error {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return fmt.Errorf("the command is empty")
+	}
+	cmd := exec.Command(fields[0], fields[1:]...)
+	cmd.Stdin = strings.NewReader(text + "\n")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if len(output) > 0 {
+			return fmt.Errorf("%v: %v", err, strings.TrimSpace(string(output)))
+		}
+		return err
+	}
+	return nil
+}
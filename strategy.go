@@ -0,0 +1,380 @@
+// strategy.go - The ways the solver can choose its next guess.
+//
+// first    guess the first word in the list that fits the clues.
+// minimax  guess the word whose worst-case response leaves the fewest
+//          candidates.
+// entropy  guess the word whose response is expected to give the most
+//          information, in bits.
+// lookahead  of the best few words by entropy, guess the one expected to
+//          take the fewest guesses in all, looking one guess further on.
+//
+// minimax, entropy and lookahead only consider words that fit the clues, so that
+// every guess could be the answer.  Words with equal scores are put in
+// order by the tie-break (see --tiebreak):
+//
+// list     the order in which the solver considers words (see --order)
+// common   the more common word first
+// alpha    alphabetical order
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const DEFAULT_STRATEGY = "first"
+
+// The most scored words printed by --show-scores.
+const MAX_SCORES_SHOWN = 10
+
+// The strategy the solver uses, set from --strategy.
+var activeStrategy = DEFAULT_STRATEGY
+
+const DEFAULT_TIEBREAK = "list"
+
+// How minimax and entropy choose between equally good words, set from
+// --tiebreak.
+var activeTiebreak = DEFAULT_TIEBREAK
+
+func isTiebreak(name string) bool {
+	return name == "list" || name == "common" || name == "alpha"
+}
+
+// Report whether a should come before b, two words with the same score,
+// according to the active tie-break.  For list, the sort is stable, so
+// leaving them as they are keeps the list's order.
+func isBetterTiebreak(a string, b string) bool {
+	if activeTiebreak == "common" {
+		return wordRank[a] < wordRank[b]
+	} else if activeTiebreak == "alpha" {
+		return a < b
+	}
+	return false
+}
+
+// If true, the solver picks at random, using rng so that --seed applies,
+// among the words the strategy rates equally, rather than by the
+// tie-break.  Set by --random-ties.
+var randomTies = false
+
+// The solver's first guess for each strategy, since it's always the same
+// for a given word list and is slow to work out.
+var firstGuesses = make(map[string]string)
+
+// All the strategies, for --compare.
+var STRATEGIES = []string{"first", "minimax", "entropy", "lookahead"}
+
+const DEFAULT_LOOKAHEAD_WIDTH = 5
+
+// How many of the best words by entropy the lookahead strategy compares,
+// set from --lookahead-k.  The cost of each guess grows with it.
+var lookaheadWidth = DEFAULT_LOOKAHEAD_WIDTH
+
+func isStrategy(name string) bool {
+	for _, strategy := range STRATEGIES {
+		if name == strategy {
+			return true
+		}
+	}
+	return false
+}
+
+// A word, and how good a guess it is under the active strategy.
+type ScoredWord struct {
+	word  string
+	score float64
+}
+
+// Return the score of guess against candidates under strategy.
+func scoreGuess(strategy string, guess string, candidates []string) float64 {
+	buckets := responseBuckets(guess, candidates)
+	if strategy == "minimax" {
+		worst := 0
+		for _, count := range buckets {
+			if count > worst {
+				worst = count
+			}
+		}
+		return float64(worst)
+	}
+	// entropy.  Sum the buckets in a fixed order: map order varies from
+	// run to run, and so would the rounding, which decides ties.
+	counts := make([]int, 0, len(buckets))
+	for _, count := range buckets {
+		counts = append(counts, count)
+	}
+	sort.Ints(counts)
+	bits := 0.0
+	for _, count := range counts {
+		probability := float64(count) / float64(len(candidates))
+		bits -= probability * math.Log2(probability)
+	}
+	return bits
+}
+
+// Return true if score a is better than score b under strategy.
+func isBetterScore(strategy string, a float64, b float64) bool {
+	if strategy == "minimax" {
+		return a < b
+	}
+	return a > b
+}
+
+// Score each of candidates as a guess under strategy, and return them best
+// first.  Words with equal scores stay in list order.  If wholeList is
+// set, candidates must be the whole word list, as they are for the first
+// guess, and the scores come from the cache on disk.
+func scoreGuesses(strategy string, candidates []string, wholeList bool) []ScoredWord {
+	scored := make([]ScoredWord, len(candidates))
+	if wholeList {
+		scores := openingScores(strategy)
+		for i, guess := range candidates {
+			scored[i] = ScoredWord{word: guess, score: scores[guess]}
+		}
+	} else {
+		for i, guess := range candidates {
+			scored[i] = ScoredWord{word: guess, score: scoreGuess(strategy, guess, candidates)}
+		}
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].score == scored[j].score {
+			return isBetterTiebreak(scored[i].word, scored[j].word)
+		}
+		return isBetterScore(strategy, scored[i].score, scored[j].score)
+	})
+	return scored
+}
+
+// Return the guess the active strategy picks from candidates, which must
+// not be empty.
+func chooseByStrategy(candidates []string, isFirstGuess bool) string {
+	if activeStrategy == "first" || len(candidates) <= 2 {
+		if randomTies {
+			return candidates[rng.Intn(len(candidates))]
+		}
+		return candidates[0]
+	}
+	if activeStrategy == "lookahead" {
+		if isFirstGuess {
+			if guess, present := firstGuesses[activeStrategy]; present {
+				return guess
+			}
+		}
+		guess := lookaheadGuesses(candidates, isFirstGuess)[0].word
+		if isFirstGuess {
+			firstGuesses[activeStrategy] = guess
+		}
+		return guess
+	}
+	if randomTies {
+		scored := scoreGuesses(activeStrategy, candidates, isFirstGuess)
+		numTied := 1
+		for numTied < len(scored) && scored[numTied].score == scored[0].score {
+			numTied++
+		}
+		return scored[rng.Intn(numTied)].word
+	}
+	if isFirstGuess {
+		if guess, present := firstGuesses[activeStrategy]; present {
+			return guess
+		}
+	}
+	guess := scoreGuesses(activeStrategy, candidates, isFirstGuess)[0].word
+	if isFirstGuess {
+		firstGuesses[activeStrategy] = guess
+	}
+	return guess
+}
+
+// Print the best few candidates with their scores under the active
+// strategy.  wholeList says whether candidates is the whole word list.
+func showScores(candidates []string, wholeList bool) {
+	if activeStrategy == "first" {
+		fmt.Println("(The first strategy does not score words; it guesses the first that fits.)")
+		return
+	}
+	var scored []ScoredWord
+	if activeStrategy == "lookahead" {
+		scored = lookaheadGuesses(candidates, wholeList)
+	} else {
+		scored = scoreGuesses(activeStrategy, candidates, wholeList)
+	}
+	if len(scored) > MAX_SCORES_SHOWN {
+		scored = scored[:MAX_SCORES_SHOWN]
+	}
+	for _, entry := range scored {
+		if activeStrategy == "lookahead" {
+			fmt.Printf("  %-*v  %6.3f guesses expected\n", LETTERS_IN_WORD, entry.word, entry.score)
+		} else if activeStrategy == "minimax" {
+			fmt.Printf("  %-*v  worst case %5.0f words\n", LETTERS_IN_WORD, entry.word, entry.score)
+		} else {
+			fmt.Printf("  %-*v  %6.3f bits\n", LETTERS_IN_WORD, entry.word, entry.score)
+		}
+	}
+}
+
+// Read the words still possible from path, one per line, and print the
+// guess the active strategy would make among them.  With scores set,
+// show the top candidates' scores first.
+func suggestFrom(path string, scores bool) error {
+	words, _, err := readWordListFile(path)
+	if err != nil {
+		return err
+	}
+	candidates := filterWordLength(path, words, false)
+	if len(candidates) == 0 {
+		fmt.Println("There are no candidates to choose from")
+		return nil
+	}
+	if scores {
+		showScores(candidates, false)
+	}
+	fmt.Println(chooseByStrategy(candidates, false))
+	return nil
+}
+
+// Estimate how many more guesses the solver will need to find the word
+// among candidates.  The next guess is one of them, so it wins outright
+// with chance 1/n; otherwise the guesses after it each cut the candidates
+// by about as much as it does, which takes log(n)/log(reduction) of them.
+// This is only a rough guide.
+func estimateGuessesLeft(candidates []string) float64 {
+	if len(candidates) <= 1 {
+		return float64(len(candidates))
+	}
+	return estimateGuessesLeftAfter(chooseByStrategy(candidates, false), candidates)
+}
+
+// Estimate, as estimateGuessesLeft does, how many more guesses it will
+// take to find the word among candidates if the next guess is guess.
+func estimateGuessesLeftAfter(guess string, candidates []string) float64 {
+	n := len(candidates)
+	if n <= 1 {
+		return float64(n)
+	}
+	remaining := expectedRemaining(responseBuckets(guess, candidates), n)
+	reduction := float64(n) / remaining
+	if reduction <= 1 {
+		// The guess tells us nothing, so we may have to try every word.
+		return float64(n+1) / 2
+	}
+	return 1 + (1-1/float64(n))*math.Log(float64(n))/math.Log(reduction)
+}
+
+// Return how many guesses, counting guess itself, it is expected to take
+// to find the word among candidates if guess is next.  After each
+// response but yyyyy, the guess after it is taken to be the best by
+// entropy among the words left, and the rest is estimated.
+func lookaheadCost(guess string, candidates []string) float64 {
+	groups := make(map[string][]string)
+	for _, word := range candidates {
+		response := evaluateGuess(guess, word)
+		groups[response] = append(groups[response], word)
+	}
+	cost := 1.0
+	for response, group := range groups {
+		if response == strings.Repeat("y", LETTERS_IN_WORD) {
+			continue
+		}
+		next := group[0]
+		if len(group) > 2 {
+			next = scoreGuesses("entropy", group, false)[0].word
+		}
+		cost += float64(len(group)) / float64(len(candidates)) * estimateGuessesLeftAfter(next, group)
+	}
+	return cost
+}
+
+// Return the lookaheadWidth best words among candidates by entropy, each
+// scored by lookaheadCost, fewest guesses first.  Words with equal costs
+// stay in entropy order.  wholeList says whether candidates is the whole
+// word list.
+func lookaheadGuesses(candidates []string, wholeList bool) []ScoredWord {
+	top := scoreGuesses("entropy", candidates, wholeList)
+	if len(top) > lookaheadWidth {
+		top = top[:lookaheadWidth]
+	}
+	scored := make([]ScoredWord, len(top))
+	for i, entry := range top {
+		scored[i] = ScoredWord{word: entry.word, score: lookaheadCost(entry.word, candidates)}
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score < scored[j].score
+	})
+	return scored
+}
+
+// Print the entropy of candidates, taking each to be equally likely: the
+// bits of information still needed to find the word.  Also print how
+// many bits the best next guess among them is expected to give.
+// wholeList says whether candidates is the whole word list.
+func printEntropy(candidates []string, wholeList bool) {
+	if len(candidates) == 0 {
+		fmt.Println("No words fit the clues")
+		return
+	}
+	fmt.Printf("Entropy: %.2f bits (%v candidates)\n",
+		math.Log2(float64(len(candidates))), len(candidates))
+	if len(candidates) > 1 {
+		best := scoreGuesses("entropy", candidates, wholeList)[0]
+		fmt.Printf("Best next guess %v is expected to give %.2f bits\n", best.word, best.score)
+	}
+}
+
+// If true, once some letters are known in place, the solver may guess a
+// word that can't be the answer, if it splits the candidates better by
+// trying new letters in the other positions.  Set by --probe-unknowns.
+var probeUnknowns = false
+
+// With fewer candidates than this, guessing one of them is as good as any
+// probe.
+const MIN_CANDIDATES_TO_PROBE = 3
+
+// Return a word to guess instead of guess, which fits the clues, to find
+// out more about the positions whose letter isn't known yet.  The probe
+// must not reuse a known letter in its known position, which would tell
+// us nothing, and must be expected to leave fewer candidates than guess.
+// If there is no such word, return guess.
+func (solver *Solver) chooseProbe(guess string) string {
+	candidates := solver.untriedCandidates()
+	if len(candidates) < MIN_CANDIDATES_TO_PROBE {
+		return guess
+	}
+	// The letter known for each position, or "" if it isn't known yet.
+	var known [LETTERS_IN_WORD]string
+	numKnown := 0
+	for ipos := 0; ipos < LETTERS_IN_WORD; ipos++ {
+		if len(solver.validLetters[ipos]) == 1 {
+			for letter := range solver.validLetters[ipos] {
+				known[ipos] = letter
+			}
+			numKnown++
+		}
+	}
+	if numKnown == 0 {
+		return guess
+	}
+	best := guess
+	bestRemaining := expectedRemaining(responseBuckets(guess, candidates), len(candidates))
+	for _, word := range AllWords {
+		reusesKnown := false
+		for ipos, letter := range []rune(word) {
+			if string(letter) == known[ipos] {
+				reusesKnown = true
+				break
+			}
+		}
+		if reusesKnown || solver.alreadyGuessed(word) {
+			continue
+		}
+		remaining := expectedRemaining(responseBuckets(word, candidates), len(candidates))
+		if remaining < bestRemaining {
+			best = word
+			bestRemaining = remaining
+		}
+	}
+	return best
+}
@@ -0,0 +1,11 @@
+// term_bsd.go - The ioctl requests for terminal settings on macOS and
+// the BSDs.
+
+//go:build darwin || freebsd || netbsd || openbsd
+
+package main
+
+import "syscall"
+
+const ioctlGetTermios = syscall.TIOCGETA
+const ioctlSetTermios = syscall.TIOCSETA
@@ -0,0 +1,8 @@
+// term_linux.go - The ioctl requests for terminal settings on Linux.
+
+package main
+
+import "syscall"
+
+const ioctlGetTermios = syscall.TCGETS
+const ioctlSetTermios = syscall.TCSETS
@@ -0,0 +1,18 @@
+// term_other.go - Systems where wordg can't turn off echo or find the
+// terminal width.
+
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !windows
+
+package main
+
+import "errors"
+
+// Echo can't be turned off here, so the error is always returned.
+func disableEcho(fd int) (restore func(), err error) {
+	return nil, errors.New("hiding input is not supported on this system")
+}
+
+// The width can't be found here, so the error is always returned.
+func terminalColumns(fd int) (int, error) {
+	return 0, errors.New("the terminal width is not known on this system")
+}
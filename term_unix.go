@@ -0,0 +1,43 @@
+// term_unix.go - Turning off echo of typed characters on Unix terminals,
+// and finding their width.
+
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package main
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// Turn off echoing of characters typed at the terminal fd.  Return a
+// function that puts the terminal back as it was.  An error means fd is
+// not a terminal.
+func disableEcho(fd int) (restore func(), err error) {
+	var state syscall.Termios
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd),
+		ioctlGetTermios, uintptr(unsafe.Pointer(&state))); errno != 0 {
+		return nil, errno
+	}
+	hidden := state
+	hidden.Lflag &^= syscall.ECHO
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd),
+		ioctlSetTermios, uintptr(unsafe.Pointer(&hidden))); errno != 0 {
+		return nil, errno
+	}
+	return func() {
+		syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd),
+			ioctlSetTermios, uintptr(unsafe.Pointer(&state)))
+	}, nil
+}
+
+// Return the width in columns of the terminal fd.  An error means fd is
+// not a terminal.
+func terminalColumns(fd int) (int, error) {
+	var size struct{ rows, cols, xpixel, ypixel uint16 }
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd),
+		syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&size))); errno != 0 {
+		return 0, errno
+	}
+	return int(size.cols), nil
+}
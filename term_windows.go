@@ -0,0 +1,49 @@
+// term_windows.go - Turning off echo of typed characters in a Windows
+// console, and finding its width.
+
+package main
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// The console mode bit that echoes typed characters.
+const ENABLE_ECHO_INPUT = 0x0004
+
+var kernel32 = syscall.NewLazyDLL("kernel32.dll")
+var setConsoleMode = kernel32.NewProc("SetConsoleMode")
+var getConsoleScreenBufferInfo = kernel32.NewProc("GetConsoleScreenBufferInfo")
+
+// Turn off echoing of characters typed at the console fd.  Return a
+// function that puts the console back as it was.  An error means fd is
+// not a console.
+func disableEcho(fd int) (restore func(), err error) {
+	handle := syscall.Handle(fd)
+	var mode uint32
+	if err := syscall.GetConsoleMode(handle, &mode); err != nil {
+		return nil, err
+	}
+	if ok, _, err := setConsoleMode.Call(uintptr(handle), uintptr(mode&^ENABLE_ECHO_INPUT)); ok == 0 {
+		return nil, err
+	}
+	return func() {
+		setConsoleMode.Call(uintptr(handle), uintptr(mode))
+	}, nil
+}
+
+// Return the width in columns of the console window fd.  An error means
+// fd is not a console.
+func terminalColumns(fd int) (int, error) {
+	// CONSOLE_SCREEN_BUFFER_INFO; the window is srWindow.
+	var info struct {
+		size, cursor             [2]int16
+		attributes               uint16
+		left, top, right, bottom int16
+		maxWindowSize            [2]int16
+	}
+	if ok, _, err := getConsoleScreenBufferInfo.Call(uintptr(fd), uintptr(unsafe.Pointer(&info))); ok == 0 {
+		return 0, err
+	}
+	return int(info.right-info.left) + 1, nil
+}
@@ -0,0 +1,108 @@
+// tree.go - Export the solver's whole strategy as a tree, for
+// --export-tree: its first guess, and for each response the word list
+// allows, the guess it makes next, and so on.  The tree can be used to
+// solve without running the solver, or drawn with Graphviz.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// One guess in the decision tree, and the guess after each response to
+// it.  The response yyyyy has no entry, since it ends the game.  Words
+// still possible when the guesses run out, or when the solver has no
+// guess to make, are listed in Unsolved.
+type DecisionNode struct {
+	Guess      string                   `json:"guess,omitempty"`
+	Candidates int                      `json:"candidates"`
+	Next       map[string]*DecisionNode `json:"next,omitempty"`
+	Unsolved   []string                 `json:"unsolved,omitempty"`
+}
+
+// Build the tree of the solver's guesses from the clues solver has,
+// allowing maxGuesses guesses in all.  Return nil if no word fits.
+func buildDecisionTree(solver *Solver, maxGuesses int) *DecisionNode {
+	candidates := solver.findCandidates()
+	if len(candidates) == 0 {
+		return nil
+	}
+	node := &DecisionNode{Candidates: len(candidates)}
+	if len(solver.history) >= maxGuesses {
+		node.Unsolved = candidates
+		return node
+	}
+	// There is no guess if every word left has been guessed already.
+	node.Guess = solver.chooseGuess()
+	if len(node.Guess) == 0 {
+		node.Unsolved = candidates
+		return node
+	}
+	for response := range responseBuckets(node.Guess, candidates) {
+		if response == strings.Repeat("y", LETTERS_IN_WORD) {
+			continue
+		}
+		// The saved board is the solver's own, so it always restores.
+		next, _ := restoreSolver(solver.save())
+		if _, err := next.processResponse(node.Guess, response); err != nil {
+			continue
+		}
+		if node.Next == nil {
+			node.Next = make(map[string]*DecisionNode)
+		}
+		node.Next[response] = buildDecisionTree(next, maxGuesses)
+	}
+	return node
+}
+
+// Write the tree to out in Graphviz DOT format, numbering the nodes from
+// *numNodes.  Return the number of the root.
+func writeDecisionDot(out *strings.Builder, node *DecisionNode, numNodes *int) int {
+	id := *numNodes
+	*numNodes++
+	if len(node.Guess) == 0 {
+		fmt.Fprintf(out, "  n%v [label=%q, shape=box];\n", id, "unsolved: "+strings.Join(node.Unsolved, " "))
+		return id
+	}
+	fmt.Fprintf(out, "  n%v [label=%q];\n", id, fmt.Sprintf("%v (%v)", node.Guess, node.Candidates))
+	var responses []string
+	for response := range node.Next {
+		responses = append(responses, response)
+	}
+	sort.Strings(responses)
+	for _, response := range responses {
+		child := writeDecisionDot(out, node.Next[response], numNodes)
+		fmt.Fprintf(out, "  n%v -> n%v [label=%q];\n", id, child, response)
+	}
+	return id
+}
+
+// Build the solver's decision tree for the word list, allowing maxGuesses
+// guesses, and write it to path: as DOT if the name ends in .dot,
+// otherwise as JSON.
+func exportDecisionTree(path string, maxGuesses int) error {
+	tree := buildDecisionTree(NewSolver(), maxGuesses)
+	if tree == nil {
+		return fmt.Errorf("the word list is empty")
+	}
+	var data []byte
+	if strings.HasSuffix(path, ".dot") {
+		var out strings.Builder
+		out.WriteString("digraph wordg {\n")
+		numNodes := 0
+		writeDecisionDot(&out, tree, &numNodes)
+		out.WriteString("}\n")
+		data = []byte(out.String())
+	} else {
+		var err error
+		data, err = json.MarshalIndent(tree, "", "  ")
+		if err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,76 @@
+// uniqueness.go - Report how hard a word is to tell apart from the other
+// words in the list, for --uniqueness.  A word with many anagrams, or
+// that looks the same as many others after the opener, can feel unfair
+// as an answer.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The most words --uniqueness lists in each part of its report.
+const MAX_ALIKE_SHOWN = 10
+
+// Return the words in the list, other than word, made of the same letters.
+func anagramsOf(word string) []string {
+	letters := sortLetters(word)
+	var anagrams []string
+	for _, other := range AllWords {
+		if other != word && sortLetters(other) == letters {
+			anagrams = append(anagrams, other)
+		}
+	}
+	return anagrams
+}
+
+// Return the words in the list, other than word, that give the same
+// response as word to guess.
+func wordsAlikeAfter(guess string, word string) []string {
+	response := evaluateGuess(guess, word)
+	var alike []string
+	for _, other := range AllWords {
+		if other != word && evaluateGuess(guess, other) == response {
+			alike = append(alike, other)
+		}
+	}
+	return alike
+}
+
+// Print how many words, and some of them, are in words, as a line of
+// the --uniqueness report.
+func printAlike(description string, words []string) {
+	shown := words
+	if len(shown) > MAX_ALIKE_SHOWN {
+		shown = shown[:MAX_ALIKE_SHOWN]
+	}
+	fmt.Printf("%v: %v", description, len(words))
+	if len(shown) > 0 {
+		fmt.Printf(" (%v", strings.Join(shown, ", "))
+		if len(shown) < len(words) {
+			fmt.Print(", ...")
+		}
+		fmt.Print(")")
+	}
+	fmt.Println()
+}
+
+// Report the other words with the same letters as word, and the other
+// words that look the same as word after the solver's first guess.
+// Return an error if word is not in the word list.
+func reportUniqueness(word string) error {
+	if !isKnownWord(word) {
+		return fmt.Errorf("%v is not in the word list", word)
+	}
+	printAlike("Anagrams", anagramsOf(word))
+	opener := NewSolver().chooseGuess()
+	if opener == word {
+		fmt.Printf("%v is the solver's first guess, so it is found at once\n", word)
+		return nil
+	}
+	description := fmt.Sprintf("Words giving the same response (%v) to the solver's first guess, %v",
+		evaluateGuess(opener, word), opener)
+	printAlike(description, wordsAlikeAfter(opener, word))
+	return nil
+}
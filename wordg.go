@@ -16,11 +16,20 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"flag"
 	"fmt"
+	"hash/fnv"
+	"math"
 	"math/rand"
 	"os"
+	"os/signal"
+	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
 )
 
 type RunType int
@@ -31,61 +40,1038 @@ const (
 	BAD RunType = iota
 	RUN
 	GUESS
+	REPLAY
+	HARDEST
+	SELFCHECK
+	MAKE_OPENINGS
+	BENCHMARK
+	COMPARE
+	FIND
+	ANALYZE
+	FROM_SHARE
+	RATE
+	SUGGEST
+	TEST
+	PROVE_SOLVABLE
+	DISTINGUISH
+	UNIQUENESS
+	EXPORT_TREE
+	DIFF_LISTS
+	MAKE_SHARE
 )
 
 const LETTERS_IN_WORD = 5
 
+// The program's exit codes, for scripts.
+const (
+	EXIT_OK          = 0   // The game was won, or the mode completed
+	EXIT_LOST        = 1   // The game ended without finding the word
+	EXIT_USAGE       = 2   // The command line was wrong
+	EXIT_IO_ERROR    = 3   // A file could not be read or written
+	EXIT_TEST_FAILED = 4   // A --test case failed, --selfcheck found a problem, or --prove-solvable found a word it can't solve
+	EXIT_INTERRUPTED = 130 // Ctrl-C was pressed, as shells report for SIGINT
+)
+
 var MyScanner bufio.Scanner
 
-// Map: index is a letter, value is the minimum number of occurrences of that
-// letter in the word we are trying to guess.  We don't populate with letters
-// that we don't yet know are required.
-var requiredLetters = make(map[string]int)
+const DEFAULT_ALPHABET = "abcdefghijklmnopqrstuvwxyz"
+
+// The letters words can be made of.  Set by --alphabet.
+var alphabet = DEFAULT_ALPHABET
+
+// Random number generator for choosing words.  It is reseeded from --seed
+// if that is given, so that games can be reproduced.
+var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+
+// A way of choosing the word for a run-mode game from the words it could be.
+type WordChooser func(words []string) string
+
+// Return a WordChooser that chooses at random using r.
+func randomChooser(r *rand.Rand) WordChooser {
+	return func(words []string) string {
+		return words[r.Intn(len(words))]
+	}
+}
+
+// How the word for a run-mode game is chosen, when it isn't given.  It
+// uses rng, so --seed applies to it.  Replacing it, say with a chooser
+// that always returns the same word, makes a game deterministic without
+// depending on how rng is seeded.
+var chooseWord = randomChooser(rng)
+
+// The word in the run-mode game in progress, or "" if there isn't one.
+// It is read by the interrupt handler, which runs in another goroutine.
+var currentSecret atomic.Value
 
 type Settings struct {
 	runType RunType
 	word    string
-	errMsg  string
+	// In run mode, other words accepted as the answer, from further --word
+	// flags.
+	alsoAccepted []string
+	boards       int
+	// In guess mode, the word to solve automatically instead of asking
+	// the user for responses.
+	target string
+	// In auto mode, print only the answer and number of guesses.
+	finalOnly bool
+	// In auto mode, guess the last remaining word rather than declaring it.
+	confirm bool
+	// In auto mode, responses to give in place of the true ones.
+	injections map[int]string
+	// In run mode, ask for the word without echoing it.
+	askSecret bool
+	// In run mode, refuse a word given with --word that isn't in the word
+	// list, rather than warning and playing it.
+	strictSecret bool
+	// In run mode, offer another game after each one, and keep a running
+	// average of the guesses taken.
+	again bool
+	// A file giving the word and the player's input, for run mode.
+	scenarioFile string
+	// In run mode, offer to list the other words that fit the clues after
+	// the word is found.
+	explore bool
+	// In run mode, let the player keep guessing after running out of
+	// guesses.  Those guesses are overtime, and finding the word then
+	// doesn't count as a win.
+	practice bool
+	// In run mode, comment on each guess.
+	coach bool
+	// In coach mode, note when an anagram of a guess would have been better.
+	coachAnagrams bool
+	// In coach mode, warn about a guess that can't be the word and can't
+	// tell apart any of the words that fit the clues.
+	coachUseless bool
+	// In run mode, the file to append a record of the game to.
+	logFile string
+	// In run mode, the PNG file to draw the grid of results to.
+	imageFile string
+	// In run mode, show the word after each guess with the letters not
+	// yet found in place hidden.
+	revealGreens bool
+	// In run mode, show only the greens in each result.
+	blind bool
+	// In run mode, show results as letters marked with symbols.
+	symbols bool
+	// In run mode, a command to pipe a spoken description of each result to.
+	ttsCommand string
+	// In run mode, the number of guesses allowed; 0 means no limit.
+	maxGuesses int
+	// In run mode, accept only guesses that are in the word list.
+	strict bool
+	// In run mode, allow one letter of a guess to be the wildcard *.
+	wildcards bool
+	// In run mode, never choose a plural or proper noun as the word.
+	noPlurals bool
+	// In run mode, the letters known in place from the start, as a pattern
+	// like c__n_.
+	locked string
+	// In run mode, the number of guesses not in the word list that are
+	// free; each one after that counts as a guess.  0 means all are free.
+	freeInvalid int
+	// In run mode, the seconds allowed for each guess; 0 means no limit.
+	timeLimit int
+	// In run mode, print the result in Wordle's share format at the end.
+	share bool
+	// The date of the puzzle, and of puzzle 0, for the share format.
+	date  string
+	epoch string
+	// In run mode, say whether each guess is warmer or colder than the last.
+	warmer bool
+	// In run mode, hints to give before guessing starts: vowels and/or distinct.
+	hints []string
+	// In run mode, what to do about a word guessed twice: notice, confirm or allow.
+	repeats string
+	// The name of the built-in word list to use, or a word list file.
+	list string
+	// The letters words can be made of.
+	alphabet string
+	// If not empty, the category of word to play with.
+	category string
+	// In run mode, the relative chance of the word coming from each
+	// category; nil means every word is equally likely.
+	categoryWeights map[string]float64
+	// File of best second guesses to load, and file to write them to.
+	openingsFile     string
+	makeOpeningsFile string
+	// The directory for cached first guesses and scores.
+	cacheDir string
+	// The solver's strategy for choosing guesses.
+	strategy string
+	// How minimax and entropy choose between equally good words.
+	tiebreak string
+	// Pick at random among equally good guesses.
+	randomTies bool
+	// How many guesses the lookahead strategy compares.
+	lookaheadWidth int
+	// Let the solver guess words that can't be the answer, to probe the
+	// positions it doesn't know yet.
+	probeUnknowns bool
+	// Make the solver's first guesses try as many vowels as they can.
+	vowelsFirst bool
+	// The solver's first guesses, overriding any cached best opener.
+	firstGuesses []string
+	// In guess mode, show the top candidates with their strategy scores.
+	showScores bool
+	// In guess mode, narrate the solver's reasoning.
+	explain bool
+	// In guess mode, show the clues as a regular expression after each
+	// response.
+	regex bool
+	// In guess mode, reprint the guesses and responses so far after each
+	// response.
+	showBoard bool
+	// In guess mode, the width of the screen for --board; 0 means find out.
+	width int
+	// In guess mode, show how much each row of --board narrowed the words.
+	tint bool
+	// In guess mode, estimate the guesses still needed after each response.
+	estimate bool
+	// In guess mode, show how many words are left, and what fraction of
+	// the word list that is, after each response.
+	showCount bool
+	// In guess mode, a session saved with the save command to carry on with.
+	resumeFile string
+	// In run mode, reveal another letter after every this many failed
+	// guesses; 0 means never.
+	teach int
+	// Print extra information, such as statistics about the word list.
+	verbose bool
+	// Files to write CPU and memory profiles to.
+	cpuProfile string
+	memProfile string
+	// The order in which the solver considers words: list, alpha or random.
+	order string
+	// Seed for the random number generator; 0 means seed from the clock.
+	seed int64
+	// The --seed given as a string rather than a number, if it was; in
+	// run mode, it decides the word.
+	seedText string
+	// Transcript file to re-score in replay mode.
+	replayFile string
+	// In hardest mode, the number of words to report.
+	numHardest int
+	// In selfcheck mode, the number of words to check.
+	numSelfCheck int
+	// In benchmark mode, a word frequency file, and the frequency a word
+	// needs to be used as a target.
+	freqFile string
+	minFreq  int
+	// In benchmark and compare modes, the number of words to sample; 0
+	// means use them all.
+	sampleSize int
+	// In suggest mode, the file of words that are still possible.
+	suggestFile string
+	// In rate mode, the word to rate.
+	rateWord string
+	// In distinguish mode, the two words to tell apart.
+	distinguish []string
+	// In uniqueness mode, the word to report on.
+	uniquenessWord string
+	// In export-tree mode, the file to write the decision tree to.
+	treeFile string
+	// In diff-lists mode, the two word lists to compare.
+	diffLists []string
+	// In from-share mode, the file holding the share block, and the
+	// words guessed for its first rows.
+	shareFile string
+	guesses   []string
+	// In find mode, the pattern to match, and the letters the words must
+	// and must not contain.
+	pattern  string
+	contains string
+	exclude  string
+	errMsg   string
 }
 
 func usage() {
 	var usageMsg = []string{
 		"wordg: Program to play Wordle.",
-		"Usage: wordg {--run | --guess } [--word=word]",
+		"Usage: wordg {--run | --guess | --replay=transcript | --hardest=n |",
+		"              --selfcheck=n | --make-openings=file |",
+		"              {--benchmark | --compare} [--freq=file --min-freq=n] [--sample=n] |",
+		"              --find [--pattern=pattern] [--contains=letters] [--exclude=letters] |",
+		"              --analyze | --from-share=file --guesses=word,word,... |",
+		"              --rate=word | --suggest-from=file | --test | --prove-solvable |",
+		"              --distinguish=word,word | --uniqueness=word |",
+		"              --export-tree=file | --diff-lists=list,list |",
+		"              --make-share --word=word --guesses=word,word,...}",
+		"   or: wordg {play | solve | benchmark | compare | analyze | find | test | prove} [flags]",
+		"             [--word=word [--word=word...] [--strict-secret] | --ask-secret | --scenario=file] [--again] [--boards=words]",
+		"             [--target=word [--final-only] [--confirm] [--inject=step:response]] [--coach [--coach-anagrams] [--coach-useless]] [--explore] [--practice]",
+		"             [--order={list | alpha | random}] [--seed={n | text}] [--warmer]",
+		"             [--hints=vowels,distinct] [--repeats={notice | confirm | allow}]",
+		"             [--max-guesses=n] [--strict=false | --free-invalid=n] [--wildcards] [--no-plurals] [--locked=pattern] [--challenge [--time-limit=seconds]]",
+		"             [--share [--date=yyyy-mm-dd] [--epoch=yyyy-mm-dd]]",
+		"             [--log=file] [--image=file] [--teach=n] [--reveal-greens] [--blind] [--symbols] [--tts-command=command] [--verbose] [--cpuprofile=file] [--memprofile=file]",
+		"             [--list=name [--category=name | --category-weights=weights]] [--alphabet=letters] [--openings=file] [--make-openings=file] [--cache-dir=dir]",
+		"             [--strategy={first | minimax | entropy | lookahead [--lookahead-k=n]} [--tiebreak={list | common | alpha}] [--random-ties] [--probe-unknowns] [--vowels-first]]",
+		"             [--show-scores] [--explain] [--regex] [--board [--width=n] [--tint]] [--estimate] [--count]",
+		"             [--resume=file] [--first-guess=word[,word...]]",
 		"where:",
 		"--run   specifies that the program should think of a word and let you guess it.",
+		"        Instead of a guess, you can type coverage word to see which letters",
+		"        of word you haven't tried yet, without guessing it. Type help or ?",
+		"        to see the rules and commands.",
 		"--guess specifies that the program should makes guesses about a word some",
-		"        other entity is thinking of.",
-		"word    applies only to --run mode, and specifies the word the program should",
+		"        other entity is thinking of. Type help or ? at the Resp: prompt",
+		"        to see how to give a response, and the other commands.",
+		"--replay re-scores the guesses in a transcript file against --word, to show",
+		"        how the responses would have differed had that been the word.",
+		"        Each line of the transcript holds a guess, optionally followed by the",
+		"        response it originally got.",
+		"--hardest runs the solver against every word in the list, and reports the n",
+		"        words that took it the most guesses, worst first.",
+		"--selfcheck solves n randomly chosen words (or all of them, if n is at",
+		"        least the size of the list), and reports any word where the clues the",
+		"        solver derives rule out the word itself, or it takes more than",
+		"        6 guesses. This is a check on the solver's internal consistency.",
+		"--benchmark runs the solver against every word in the list, and reports the",
+		"        average and worst number of guesses, and how many words it failed on.",
+		"--prove-solvable runs the solver, with the chosen strategy and first",
+		"        guesses, against every word in the list, and lists any word it can't",
+		"        find within --max-guesses guesses (default 6). Unlike in auto mode,",
+		"        the guess of the last remaining word counts, as it does in Wordle.",
+		"        It exits with code 4 if there are any such words.",
+		"--export-tree writes the solver's whole strategy to a file: its first",
+		"        guess, the guess it makes after each response the word list allows,",
+		"        and so on, up to --max-guesses guesses (default 6). The file is in",
+		"        Graphviz DOT format if its name ends in .dot, otherwise JSON. Words",
+		"        still possible when the guesses run out are listed as unsolved.",
+		"--diff-lists compares two word lists, each a built-in list or a file, and",
+		"        prints how many words are in each and in both, and the words only",
+		"        in one of them. With --verbose it lists the shared words too.",
+		"--compare runs the benchmark once for each strategy (first, minimax,",
+		"        entropy and lookahead), and prints the results side by side.",
+		"--sample makes --benchmark and --compare use n words chosen at random.",
+		"--freq  names a file of word frequencies, each line a word and a count. With",
+		"        --benchmark, only words with a count of at least --min-freq are used.",
+		"--find  lists, in alphabetical order, the words that match --pattern (such as",
+		"        c_a_e, with _ for unknown letters), contain all of the letters of",
+		"        --contains, and contain none of the letters of --exclude.",
+		"--analyze works out the most informative first guess for the word list, and",
+		"        caches it. From then on, the solver starts with that guess whenever it",
+		"        uses that list, whatever the strategy. Changing the list's contents",
+		"        means running --analyze again.",
+		"--cache-dir is the directory where --analyze caches the first guess, and",
+		"        where the minimax and entropy strategies cache the score of every",
+		"        word as a first guess, which is slow to work out. The default is",
+		"        wordg in your cache directory, such as ~/.cache/wordg. The scores",
+		"        are cached separately for each word list; delete the files to",
+		"        have them worked out again.",
+		"--from-share reads a result shared from Wordle, pasted into a file, and",
+		"        lists the words that could have been the answer given --guesses,",
+		"        the words guessed for the first rows, separated by commas.",
+		"--make-share prints just the share block for a game you already played:",
+		"        --word is the answer and --guesses the words you guessed, in order,",
+		"        separated by commas. --date, --epoch and --max-guesses apply as for",
+		"        --share. The guesses must be in the word list unless --strict=false.",
+		"--test  runs a built-in battery of known cases, such as guesses with repeated",
+		"        letters, to check that this copy of wordg scores guesses and applies",
+		"        responses correctly, and reports any that fail.",
+		"--suggest-from reads a file of the words that are still possible, one per",
+		"        line, and prints the guess the --strategy would make among them.",
+		"        With --show-scores, it first shows the top candidates' scores.",
+		"--rate  reports how hard a word is for the solver: how many guesses it takes",
+		"        with the chosen --strategy, and how many words fit the response to",
+		"        each of some common first guesses. The rating is the average of log2",
+		"        of those numbers; 0 is easiest.",
+		"--distinguish finds a guess whose response tells the two words apart, for",
+		"        when you're stuck between them, and shows the response each would",
+		"        give. One of the two words themselves is best, if it is in the word",
+		"        list, since it might be the answer; other guesses that work are listed too.",
+		"--uniqueness reports how many other words in the list are anagrams of the",
+		"        given word, and how many give the same response to the solver's",
+		"        first guess. A word with many of either is hard to pin down.",
+		"word    in --run mode, specifies the word the program should",
 		"        think of. Optional; the default is for wordg to select aa word randomly.",
+		"        Required in --replay mode.",
+		"        In --run mode, --word can be given more than once, for puzzles with",
+		"        several equally good answers. The first is the word the program",
+		"        thinks of and scores guesses against, but guessing any of them wins.",
+		"--strict-secret applies only to --run mode, and refuses a --word or --ask-secret word that is",
+		"        not in the word list. By default such a word is played anyway,",
+		"        with a warning, since whoever chooses the word is the host.",
+		"--ask-secret applies only to --run mode, and prompts for the word the",
+		"        program should think of without showing it as it is typed, so one",
+		"        player can choose the word for another to guess.",
+		"--again applies only to --run mode, and after each game asks whether",
+		"        to play another, with a new word. After each game it shows how",
+		"        many games you have won so far, and the average number of guesses",
+		"        they took. It cannot be used with --word or --scenario.",
+		"--scenario plays a --run game from a file, for demonstrations and",
+		"        regression tests. After any comments (lines starting with #), the",
+		"        file has a line \"secret word\", then one line for each guess, or",
+		"        anything else you could type, in order. --run can be omitted.",
+		"--coach applies only to --run mode, and comments on each of your guesses.",
+		"        At the end of the game it lists the letters you never tried (as does",
+		"        --verbose).",
+		"--coach-anagrams makes the coach also say when a different arrangement of",
+		"        the letters of your guess would have been a better probe.",
+		"--coach-useless makes the coach also warn when a guess can't be the word",
+		"        and every word that still fits the clues would give it the same",
+		"        response, so that it tells you nothing new.",
+		"--explore applies only to --run mode. Once you find the word, it offers to",
+		"        list the other words that would have fit the results of your",
+		"        earlier guesses.",
+		"--practice applies only to --run mode. When you run out of guesses, you can",
+		"        keep guessing in overtime until you find the word or type q, which",
+		"        shows it. The game still counts as lost. It implies --max-guesses=6",
+		"        unless --max-guesses is given.",
+		"--max-guesses applies only to --run mode, and is the number of guesses you",
+		"        have to find the word. The default, 0, means there is no limit.",
+		"        With --prove-solvable, it is the number the solver has (default 6).",
+		"--strict applies only to --run mode, and is on by default: a guess that is",
+		"        not in the word list is rejected, and doesn't count as a guess.",
+		"        With --strict=false, any 5 letters are accepted as a guess.",
+		"--wildcards applies only to --run mode, and lets one letter of a guess be",
+		"        *, as in cr*ne, for a slot you don't want to fill. The * is always",
+		"        marked n, and the rest of the guess is scored as usual. A guess",
+		"        with a * is not checked against the word list.",
+		"--locked applies only to --run mode, and starts the game with some letters",
+		"        known in place, as when carrying on with a game played on paper.",
+		"        It is a pattern like c__n_, with _ (or .) for the other positions.",
+		"        The word must fit it. A guess must keep the locked letters, but can",
+		"        leave them as _, as in _rane, to have them filled in.",
+		"--no-plurals applies only to --run mode, and never chooses a plural or a",
+		"        proper noun as the word, as Wordle doesn't. A word ending in s is",
+		"        taken to be a plural unless it ends in ss, us or is (as class, bonus",
+		"        and basis do); a proper noun is a word capitalized in a word list",
+		"        file. Such words can still be guessed.",
+		"--free-invalid applies only to --run mode. After n guesses that are not in",
+		"        the word list, each further one counts as a guess, as a guard",
+		"        against trying every combination of letters. The default, 0,",
+		"        means they never count.",
+		"--challenge applies only to --run mode, and gives you --time-limit seconds",
+		"        (default 30) for each guess. If you take longer, that counts as a",
+		"        guess. Unless --max-guesses is given, you have 6 guesses.",
+		"--share applies only to --run mode, and at the end of the game prints the",
+		"        result the way Wordle does when you share it. The puzzle number is",
+		"        the number of days from --epoch (default 2021-06-19, which was",
+		"        puzzle 0) to --date (default today).",
+		"--warmer applies only to --run mode, and after each guess says whether it",
+		"        was warmer or colder than the one before: that is, whether it had more",
+		"        or fewer letters marked y or p.",
+		"--hints applies only to --run mode, and before you start guessing, tells",
+		"        you how many vowels (a, e, i, o, u) and/or how many distinct letters",
+		"        the word has. Separate the two with a comma to get both.",
+		"--repeats applies only to --run mode, and says what to do if you guess the",
+		"        same word twice: notice (the default) just says so, confirm asks",
+		"        whether you really want to guess it again, and allow says nothing.",
+		"--teach applies only to --run mode. After every n guesses that don't find the",
+		"        word, one more letter of it is revealed, until the whole word is shown.",
+		"--log   applies only to --run mode, and at the end of each game appends a",
+		"        line to the file describing it in JSON: the word, the guesses and",
+		"        results, whether you won, lost or quit, the number of guesses, the",
+		"        time taken, and the flags that were given.",
+		"--image applies only to --run mode, and when the game is won or lost",
+		"        writes the grid of results to the file as a PNG picture, with",
+		"        squares colored as Wordle colors them.",
+		"--reveal-greens applies only to --run mode, and is an easy mode for young",
+		"        players: after each guess it shows the word hangman-style, with",
+		"        each letter you have found in its place and _ for the others.",
+		"--blind applies only to --run mode, and is a harder variant: results show only",
+		"        letters in the correct spot (y); the others are all shown as -, whether",
+		"        or not they are in the word. It cannot be used with --coach.",
+		"--tts-command applies only to --run mode, and gives a text to speech",
+		"        command, such as espeak, to read each result aloud. It is split",
+		"        into words at spaces and run without a shell. A description of the",
+		"        result, letter by letter, is piped to its standard input. If the",
+		"        command fails, wordg says so and carries on without it.",
+		"--symbols applies only to --run mode, and shows each result as the letters of",
+		"        your guess, each marked with a symbol rather than y, p or n:",
+		"        ✓ in the correct spot, ~ in the word elsewhere, ✗ not in the word.",
+		"--list  is the name of the built-in word list to use: english (the default,",
+		"        about 2800 words) or common (the 1000 most frequent of those), or else",
+		"        a file of words, one per line. Words that are not 5 letters long are",
+		"        dropped from the list, with a warning if there are many of them.",
+		"        In a file, each word may be followed by a category, such as animals.",
+		"        Capitalized words in a file are taken to be proper nouns (see --no-plurals).",
+		"--category-weights applies only to --run mode, and makes the word come from",
+		"        each category with the given relative chance, such as",
+		"        other=70,animals=30: that is, 70% from the words with no category",
+		"        (named other) and 30% from animals. Categories not listed are",
+		"        never chosen. Without it, every word is equally likely.",
+		"--alphabet is the letters words can be made of, for word lists in other",
+		"        languages. Every word in the list must use only these letters.",
+		"        The default is the 26 letters a to z. Each character counts as",
+		"        a letter, so a list of words made of five emoji works too.",
+		"--category restricts the word list to the words in that category. In --run",
+		"        mode, the category of the word, if it has one, is shown as a hint.",
+		"--openings loads a table of the best second guess for each response to the",
+		"        solver's first guess. The solver uses it for its second guess, when",
+		"        the table has an entry, and otherwise works out the guess as usual.",
+		"--make-openings works out such a table for the current word list and order,",
+		"        and writes it to a file. Each line is: first-guess response second-guess.",
+		"--strategy is how the solver chooses its guesses: first (the default) guesses",
+		"        the first word that fits the clues, minimax the word whose worst",
+		"        response leaves the fewest words, and entropy the word whose response",
+		"        is expected to give the most information. lookahead is slower: of",
+		"        the best --lookahead-k words by entropy (default 5), it guesses the",
+		"        one expected to take the fewest guesses in all, allowing for the",
+		"        best guess by entropy after each response to it.",
+		"--tiebreak is how minimax and entropy choose between words with the same",
+		"        score. They only ever guess words that fit the clues, so every",
+		"        guess could be the answer; among those, list (the default) takes",
+		"        the word the solver considers first (see --order), common the",
+		"        word nearer the start of the word list, which for the built-in",
+		"        lists is the more common word, and alpha the first alphabetically.",
+		"--random-ties makes the solver pick at random among the words its strategy",
+		"        rates equally, instead of by --tiebreak, for variety from game to",
+		"        game. With the first strategy, that is any word that fits the",
+		"        clues. Use --seed to make the choices repeatable.",
+		"--probe-unknowns lets the solver, once it knows the letter in some",
+		"        positions, guess a word that can't be the answer, when trying new",
+		"        letters in the other positions is expected to narrow the words",
+		"        down further. Such a probe never reuses a letter in the position",
+		"        where it is known to be. Without it, the solver plays as Wordle's",
+		"        hard mode requires, and every guess fits the clues.",
+		"--vowels-first makes the solver's first two guesses the words that try",
+		"        the most vowels it hasn't tried yet, as many players do, whether",
+		"        or not they fit the clues. Then the strategy takes over. It is",
+		"        ignored for guesses fixed by --first-guess, and replaces the",
+		"        first guess found by --analyze. Use it with --benchmark or --compare",
+		"        to measure the effect.",
+		"--show-scores applies only to --guess mode, and before each guess shows the",
+		"        top candidates with their minimax or entropy scores.",
+		"--verbose prints extra information, such as statistics about the word list:",
+		"        its size, how many words repeat a letter, and which letters are commonest.",
+		"        In --guess mode, after each response it shows the entropy of the",
+		"        candidates (the bits of information still needed to find the word)",
+		"        and how many bits the best next guess is expected to give. Typing",
+		"        entropy at the Resp: prompt does the same. With --strategy=first, it",
+		"        also shows how far into the word list the solver looked for its guess,",
+		"        and why it passed over the words before it.",
+		"--cpuprofile and --memprofile write CPU and memory profiles of the run to",
+		"        the given files, for use with go tool pprof.",
+		"--regex applies only to --guess mode, and after each response shows the",
+		"        clues as a regular expression, such as ^[abc][^xy].r.$, followed by",
+		"        the letters known to be in the word. Typing regex at the Resp:",
+		"        prompt does the same.",
+		"--board applies only to --guess mode, and after each response shows all",
+		"        the guesses so far with the responses you gave, one per line.",
+		"        A line too long for the screen is wrapped. The width of the screen",
+		"        is found from the terminal, or $COLUMNS, or else taken to be 80;",
+		"        --width=n sets it instead. With --tint, each row also shows how many",
+		"        words were left after it, and is brighter the more it narrowed",
+		"        them down, from dim gray for a guess that told nothing to white.",
+		"--estimate applies only to --guess mode, and after each response gives a",
+		"        rough estimate of how many more guesses the solver will need.",
+		"--count applies only to --guess mode, and after each response shows how",
+		"        many words still fit, out of the whole word list, and what",
+		"        percentage of it that is.",
+		"--explain applies only to --guess mode with one board, and explains in plain",
+		"        English what each response told the solver and why it chose its guess.",
+		"--first-guess is the solver's first guess, overriding the one found by",
+		"        --analyze. Give several words, separated by commas, to fix the",
+		"        solver's first few guesses whatever the responses, as with the",
+		"        two fixed openers some players use; the strategy takes over after",
+		"        them. Use it with --benchmark or --compare to measure the effect.",
+		"--resume applies only to --guess mode, and carries on with a session saved",
+		"        by typing \"save file\" at the Resp: prompt.",
+		"--order is the order in which the solver considers words, which decides",
+		"        which of several matching words it guesses: list (the order of the",
+		"        word list, the default), alpha (alphabetical) or random.",
+		"--seed  seeds the random choices, such as the word in --run mode and",
+		"        --order=random, so they can be reproduced. Default is to use the clock.",
+		"        The seed can also be text, such as birthday2024, which always gives",
+		"        the same word in --run mode with the same word list.",
+		"n       applies only to --guess mode, and is the number of words to guess at",
+		"        once, Quordle-style. Each guess applies to every unsolved board. Default 1.",
+		"--target applies only to --guess mode, and makes the program solve the given",
+		"        word by itself (auto mode), working out each response on its own.",
+		"--final-only applies only to auto mode, and suppresses the intermediate",
+		"        guesses, printing just the answer and the number of guesses.",
+		"        In auto mode (and in --hardest), once only one word fits the clues,",
+		"        the solver declares it the answer without guessing it, and that",
+		"        final guess is not counted.",
+		"--confirm makes the solver guess the last remaining word and count that guess,",
+		"        as you would have to in Wordle.",
+		"--inject applies only to auto mode, and gives the solver a wrong response,",
+		"        to test how it copes with a host that lies. For example, 2:nnpny",
+		"        replaces the response to the second guess. Separate several with commas.",
+		"Instead of a mode flag, the mode can be given as a subcommand, followed",
+		"        by the other flags: wordg play (--run), solve (--guess), benchmark,",
+		"        compare, analyze, find, test, or prove (--prove-solvable). For",
+		"        example, wordg solve --target=crane. A subcommand accepts only the",
+		"        flags that apply to its mode, so wordg solve --share is an error.",
+		"Defaults for any flag but the mode flags, such as --strategy, --list,",
+		"        --symbols or --max-guesses, can be given in a JSON config file,",
+		"        ~/.wordg.json or the file named by $WORDG_CONFIG, as an object",
+		"        keyed by flag name: {\"strategy\": \"entropy\", \"max-guesses\": 6}.",
+		"        A flag on the command line overrides the config file, which overrides the",
+		"        built-in default. A flag such as --word that can be repeated",
+		"        takes only the command line's values if it is given there.",
+		"Exit codes: 0 if the game was won or the mode completed, 1 if a game ended",
+		"        without the word being found, 2 for a command line error, 3 if a",
+		"        file could not be read or written, 4 if a --test case failed,",
+		"        --selfcheck found a problem or --prove-solvable found a word the",
+		"        solver can't solve, and 130 if wordg was interrupted with Ctrl-C",
+		"        before it finished.",
 	}
 	for _, line := range usageMsg {
 		fmt.Println(line)
 	}
 }
 
-func parseCmdLine() Settings {
+// The subcommands, such as wordg play, and the flag for the mode each
+// selects.  They are another way of giving that flag.
+var SUBCOMMANDS = map[string]string{
+	"play":      "run",
+	"solve":     "guess",
+	"benchmark": "benchmark",
+	"compare":   "compare",
+	"analyze":   "analyze",
+	"find":      "find",
+	"test":      "test",
+	"prove":     "prove-solvable",
+}
+
+// The flags every subcommand accepts: the word list, and other settings
+// that apply to every mode.
+var COMMON_FLAGS = []string{"list", "alphabet", "category", "cache-dir", "order", "seed",
+	"verbose", "cpuprofile", "memprofile"}
+
+// The flags that set how the solver chooses its guesses.
+var SOLVER_FLAGS = []string{"strategy", "lookahead-k", "random-ties", "tiebreak",
+	"probe-unknowns", "vowels-first", "openings", "first-guess"}
+
+// The flags each subcommand accepts, besides COMMON_FLAGS.
+var SUBCOMMAND_FLAGS = map[string][]string{
+	"play": {"word", "scenario", "again", "ask-secret", "strict-secret", "explore", "practice",
+		"coach", "coach-anagrams", "coach-useless", "max-guesses", "strict", "wildcards", "locked",
+		"no-plurals", "free-invalid", "challenge", "time-limit", "share", "date", "epoch", "warmer",
+		"hints", "repeats", "blind", "symbols", "tts-command", "log", "image", "reveal-greens",
+		"teach", "category-weights"},
+	"solve": append([]string{"boards", "target", "final-only", "show-scores", "count", "estimate",
+		"board", "tint", "width", "regex", "explain", "resume", "confirm", "inject"}, SOLVER_FLAGS...),
+	"benchmark": append([]string{"sample", "freq", "min-freq"}, SOLVER_FLAGS...),
+	"compare":   append([]string{"sample", "freq", "min-freq"}, SOLVER_FLAGS...),
+	"analyze":   SOLVER_FLAGS,
+	"find":      {"pattern", "contains", "exclude"},
+	"test":      {},
+	"prove":     append([]string{"max-guesses"}, SOLVER_FLAGS...),
+}
+
+// Return a flag set called name with only the flags of all that
+// subcommand accepts.  They share their values with all.
+func subcommandFlags(name string, subcommand string, all *flag.FlagSet) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	for _, flagNames := range [][]string{COMMON_FLAGS, SUBCOMMAND_FLAGS[subcommand]} {
+		for _, flagName := range flagNames {
+			f := all.Lookup(flagName)
+			flags.Var(f.Value, f.Name, f.Usage)
+		}
+	}
+	return flags
+}
+
+// The flags that choose the mode.  They can't be set in the config
+// file, since only one mode can be chosen.
+var MODE_FLAGS = []string{"run", "guess", "replay", "hardest", "selfcheck", "make-openings",
+	"benchmark", "compare", "find", "analyze", "from-share", "rate", "suggest-from", "test",
+	"prove-solvable", "distinguish", "uniqueness", "export-tree", "diff-lists", "make-share"}
+
+// The values of --word, which can be given more than once.
+type wordFlag []string
+
+func (words *wordFlag) String() string {
+	return strings.Join(*words, ",")
+}
+
+func (words *wordFlag) Set(word string) error {
+	*words = append(*words, word)
+	return nil
+}
+
+// The flags parsed from the command line, for --log to record.
+var commandLine = flag.CommandLine
+
+// Parse the command line, args, which doesn't include the program name.
+func parseCmdLine(args []string) Settings {
 	var settings Settings
+	// A subcommand, such as play, selects the mode that its mode flag
+	// would, and gets a flag set of its own for the flags after it.
+	name := "wordg"
+	subcommand := ""
+	modeFlag := ""
+	if len(args) > 0 {
+		if flagName, present := SUBCOMMANDS[args[0]]; present {
+			name = "wordg " + args[0]
+			subcommand = args[0]
+			modeFlag = flagName
+			args = args[1:]
+		}
+	}
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
 	var run bool
 	var guess bool
-	flag.BoolVar(&run, "run", false, "Have the program think of a word and make you guess")
-	flag.BoolVar(&guess, "guess", false, "Have the program try to guess the word")
-	flag.StringVar(&settings.word, "word", "", "The word the program is thinking of in run mode. If not supplied, the program will chose a word at random.")
+	flags.BoolVar(&run, "run", false, "Have the program think of a word and make you guess")
+	flags.BoolVar(&guess, "guess", false, "Have the program try to guess the word")
+	var words wordFlag
+	flags.Var(&words, "word", "The word the program is thinking of in run mode. If not supplied, the program will chose a word at random.")
 
-	flag.Parse()
+	flags.IntVar(&settings.boards, "boards", 1, "The number of words to guess at once in guess mode")
+	flags.StringVar(&settings.target, "target", "", "In guess mode, solve this word automatically")
+	flags.BoolVar(&settings.finalOnly, "final-only", false, "In auto mode, print only the answer and number of guesses")
+	flags.StringVar(&settings.scenarioFile, "scenario", "", "Play a run-mode game from a file giving the word and guesses")
+	flags.BoolVar(&settings.again, "again", false, "In run mode, offer another game after each one and show the average guesses")
+	flags.BoolVar(&settings.askSecret, "ask-secret", false, "In run mode, prompt for the word without echoing it")
+	flags.BoolVar(&settings.strictSecret, "strict-secret", false, "In run mode, refuse a --word or --ask-secret word that is not in the word list")
+	flags.BoolVar(&settings.explore, "explore", false, "In run mode, offer to list other words that fit the clues after winning")
+	flags.BoolVar(&settings.practice, "practice", false, "In run mode, keep guessing in overtime after running out of guesses")
+	flags.BoolVar(&settings.coach, "coach", false, "In run mode, comment on each guess")
+	flags.BoolVar(&settings.coachAnagrams, "coach-anagrams", false, "In coach mode, note better anagrams of each guess")
+	flags.BoolVar(&settings.coachUseless, "coach-useless", false, "In coach mode, warn about guesses that can't win and give no new information")
+	flags.IntVar(&settings.maxGuesses, "max-guesses", 0, "In run mode, --prove-solvable and --export-tree, the number of guesses allowed; 0 means no limit")
+	flags.BoolVar(&settings.strict, "strict", true, "In run mode, accept only guesses in the word list")
+	flags.BoolVar(&settings.wildcards, "wildcards", false, "In run mode, allow * for one letter of a guess")
+	flags.StringVar(&settings.locked, "locked", "", "In run mode, letters known in place from the start, like c__n_")
+	flags.BoolVar(&settings.noPlurals, "no-plurals", false, "In run mode, never choose a plural or proper noun as the word")
+	flags.IntVar(&settings.freeInvalid, "free-invalid", 0, "In run mode, the number of invalid guesses that don't count; 0 means no limit")
+	var challenge bool
+	var timeLimit int
+	flags.BoolVar(&challenge, "challenge", false, "In run mode, allow only a limited time for each guess")
+	flags.IntVar(&timeLimit, "time-limit", 30, "In challenge mode, the seconds allowed for each guess")
+	flags.BoolVar(&settings.share, "share", false, "In run mode, print the result in Wordle's share format")
+	flags.StringVar(&settings.date, "date", "", "The date of the puzzle for --share, as yyyy-mm-dd; default today")
+	flags.StringVar(&settings.epoch, "epoch", DEFAULT_EPOCH, "The date of puzzle 0 for --share")
+	flags.BoolVar(&settings.warmer, "warmer", false, "In run mode, say whether each guess is warmer or colder than the last")
+	var hints string
+	flags.StringVar(&hints, "hints", "", "In run mode, hints to give at the start: vowels and/or distinct")
+	flags.StringVar(&settings.repeats, "repeats", "notice", "In run mode, what to do about repeated guesses: notice, confirm or allow")
+	flags.BoolVar(&settings.blind, "blind", false, "In run mode, show only the letters in the correct spot")
+	flags.BoolVar(&settings.symbols, "symbols", false, "In run mode, mark the letters of each result with symbols")
+	flags.StringVar(&settings.ttsCommand, "tts-command", "", "In run mode, a text to speech command to read each result aloud")
+	flags.StringVar(&settings.logFile, "log", "", "In run mode, append a JSON record of each game to this file")
+	flags.StringVar(&settings.imageFile, "image", "", "In run mode, write the grid of results to this file as a PNG")
+	flags.BoolVar(&settings.revealGreens, "reveal-greens", false, "In run mode, show the word with the letters not yet found hidden")
+	flags.IntVar(&settings.teach, "teach", 0, "In run mode, reveal a letter after every n failed guesses")
+	flags.StringVar(&settings.list, "list", DEFAULT_WORD_LIST, "The built-in word list to use ("+wordListNames()+"), or a file of words")
+	flags.StringVar(&settings.cacheDir, "cache-dir", "", "Directory for cached first guesses and scores; default is the user cache directory")
+	flags.StringVar(&settings.openingsFile, "openings", "", "File of best second guesses for the solver to use")
+	flags.StringVar(&settings.makeOpeningsFile, "make-openings", "", "Work out the best second guesses and write them to this file")
+	flags.StringVar(&settings.strategy, "strategy", DEFAULT_STRATEGY, "How the solver chooses guesses: first, minimax, entropy or lookahead")
+	flags.IntVar(&settings.lookaheadWidth, "lookahead-k", DEFAULT_LOOKAHEAD_WIDTH, "How many of the best guesses by entropy the lookahead strategy compares")
+	flags.BoolVar(&settings.randomTies, "random-ties", false, "Pick at random among equally good guesses, rather than by --tiebreak")
+	flags.StringVar(&settings.tiebreak, "tiebreak", DEFAULT_TIEBREAK, "How minimax and entropy choose between equal words: list, common or alpha")
+	flags.BoolVar(&settings.probeUnknowns, "probe-unknowns", false, "Let the solver guess words that can't be the answer, to probe unknown positions")
+	flags.BoolVar(&settings.vowelsFirst, "vowels-first", false, "Make the solver's first two guesses try as many vowels as they can")
+	flags.BoolVar(&settings.showScores, "show-scores", false, "In guess mode, show the top candidates with their scores")
+	flags.BoolVar(&settings.verbose, "verbose", false, "Print extra information, such as word list statistics")
+	flags.StringVar(&settings.cpuProfile, "cpuprofile", "", "Write a CPU profile to this file")
+	flags.StringVar(&settings.memProfile, "memprofile", "", "Write a memory profile to this file")
+	flags.BoolVar(&settings.showCount, "count", false, "In guess mode, show how many words fit, out of the word list, after each response")
+	flags.BoolVar(&settings.estimate, "estimate", false, "In guess mode, estimate the guesses still needed after each response")
+	flags.BoolVar(&settings.showBoard, "board", false, "In guess mode, show the guesses and responses so far after each response")
+	flags.BoolVar(&settings.tint, "tint", false, "With --board, show how much each row narrowed the words, as its brightness")
+	flags.IntVar(&settings.width, "width", 0, "The width of the screen for --board; default is to find it out")
+	flags.BoolVar(&settings.regex, "regex", false, "In guess mode, show the clues as a regular expression")
+	flags.BoolVar(&settings.explain, "explain", false, "In guess mode, explain the solver's reasoning")
+	var firstGuesses string
+	flags.StringVar(&firstGuesses, "first-guess", "", "The solver's first guess, or first guesses separated by commas")
+	flags.StringVar(&settings.resumeFile, "resume", "", "In guess mode, carry on with a saved session")
+	flags.StringVar(&settings.alphabet, "alphabet", DEFAULT_ALPHABET, "The letters words can be made of")
+	flags.StringVar(&settings.category, "category", "", "Use only the words of the list in this category")
+	var categoryWeights string
+	flags.StringVar(&categoryWeights, "category-weights", "", "In run mode, the chance of the word coming from each category")
+	flags.StringVar(&settings.order, "order", "list", "Order in which the solver considers words: list, alpha or random")
+	var seed string
+	flags.StringVar(&seed, "seed", "0", "Seed for random choices, a number or text; 0 means use the clock")
+	flags.BoolVar(&settings.confirm, "confirm", false, "In auto mode, guess the last remaining word rather than declaring it")
+	var injections string
+	flags.StringVar(&injections, "inject", "", "In auto mode, give these step:response responses in place of the true ones")
+	flags.StringVar(&settings.replayFile, "replay", "", "Transcript of guesses to re-score against --word")
+	flags.IntVar(&settings.numHardest, "hardest", 0, "Report the n words the solver finds hardest")
+	flags.IntVar(&settings.numSelfCheck, "selfcheck", 0, "Check the solver's consistency on n random words")
+	var benchmarkMode bool
+	flags.BoolVar(&benchmarkMode, "benchmark", false, "Run the solver against every word and report how it did")
+	var compareMode bool
+	flags.BoolVar(&compareMode, "compare", false, "Run the benchmark for each strategy and compare them")
+	flags.IntVar(&settings.sampleSize, "sample", 0, "In benchmark and compare modes, the number of words to use")
+	flags.StringVar(&settings.freqFile, "freq", "", "File of word frequencies")
+	flags.IntVar(&settings.minFreq, "min-freq", 0, "In benchmark mode, the frequency a word needs to be used")
+	flags.StringVar(&settings.shareFile, "from-share", "", "File holding a shared result to work back from")
+	var guesses string
+	flags.StringVar(&guesses, "guesses", "", "In from-share and make-share modes, the words guessed, separated by commas")
+	var testMode bool
+	flags.BoolVar(&testMode, "test", false, "Run a built-in battery of known cases")
+	var proveMode bool
+	flags.BoolVar(&proveMode, "prove-solvable", false, "Check that the solver can find every word within the guess limit")
+	flags.StringVar(&settings.suggestFile, "suggest-from", "", "File of possible words to suggest a guess among")
+	flags.StringVar(&settings.rateWord, "rate", "", "Report how hard this word is for the solver")
+	var distinguishWords string
+	flags.StringVar(&distinguishWords, "distinguish", "", "Two words, separated by a comma, to find a guess that tells apart")
+	var makeShare bool
+	flags.BoolVar(&makeShare, "make-share", false, "Print the share block for --guesses against --word")
+	var diffLists string
+	flags.StringVar(&diffLists, "diff-lists", "", "Two word lists, separated by a comma, to compare")
+	flags.StringVar(&settings.treeFile, "export-tree", "", "Write the solver's decision tree to this file, as JSON or (for .dot) DOT")
+	flags.StringVar(&settings.uniquenessWord, "uniqueness", "", "Report how many words look like this one: anagrams, and words alike after the first guess")
+	var findMode bool
+	flags.BoolVar(&findMode, "find", false, "List the words matching --pattern, --contains and --exclude")
+	flags.StringVar(&settings.pattern, "pattern", "", "In find mode, the pattern to match, like c_a_e")
+	flags.StringVar(&settings.contains, "contains", "", "In find mode, letters the words must contain")
+	flags.StringVar(&settings.exclude, "exclude", "", "In find mode, letters the words must not contain")
+	var analyzeMode bool
+	flags.BoolVar(&analyzeMode, "analyze", false, "Work out and cache the best first guess for the word list")
+
+	// A subcommand accepts only the flags for its mode.
+	allFlags := flags
+	if len(subcommand) > 0 {
+		flags = subcommandFlags(name, subcommand, allFlags)
+	}
+	commandLine = flags
 
-	if (run && guess) || (!run && !guess) {
-		settings.errMsg = "You must specify either --guess or --run"
+	// The config file's values replace the defaults of the flags not
+	// given on the command line.
+	flags.Parse(args)
+	given := make(StringSet)
+	flags.Visit(func(f *flag.Flag) { given.Add(f.Name) })
+	if err := applyConfig(flags, allFlags, given); err != nil {
+		settings.errMsg = err.Error()
+	}
+	if len(modeFlag) > 0 {
+		allFlags.Set(modeFlag, "true")
+	}
+
+	// The words are checked against the alphabet in run, once it is set.
+	if len(words) > 0 {
+		settings.word = strings.ToLower(words[0])
+		for _, word := range words[1:] {
+			settings.alsoAccepted = append(settings.alsoAccepted, strings.ToLower(word))
+		}
+	}
+	if len(hints) > 0 {
+		settings.hints = strings.Split(hints, ",")
+	}
+	if len(categoryWeights) > 0 {
+		weights, err := parseCategoryWeights(categoryWeights)
+		if err != nil {
+			settings.errMsg = err.Error()
+		}
+		settings.categoryWeights = weights
+	}
+	if len(firstGuesses) > 0 {
+		settings.firstGuesses = strings.Split(strings.ToLower(firstGuesses), ",")
+		for _, guess := range settings.firstGuesses {
+			if utf8.RuneCountInString(guess) != LETTERS_IN_WORD {
+				settings.errMsg = fmt.Sprintf("--first-guess words must be %v letters long", LETTERS_IN_WORD)
+			}
+		}
+	}
+	if len(injections) > 0 {
+		var err error
+		settings.injections, err = parseInjections(injections)
+		if err != nil {
+			settings.errMsg = err.Error()
+		}
+	}
+	if len(guesses) > 0 {
+		settings.guesses = strings.Split(strings.ToLower(guesses), ",")
+	}
+	for _, hint := range settings.hints {
+		if hint != "vowels" && hint != "distinct" {
+			settings.errMsg = "--hints must be vowels, distinct, or both separated by a comma"
+		}
+	}
+
+	if settings.practice && settings.maxGuesses == 0 {
+		settings.maxGuesses = MAX_GUESSES
+	}
+	if challenge {
+		settings.timeLimit = timeLimit
+		if settings.maxGuesses == 0 {
+			settings.maxGuesses = MAX_GUESSES
+		}
+		if timeLimit <= 0 {
+			settings.errMsg = "--time-limit must be at least 1 second"
+		}
+	}
+	if settings.share || makeShare {
+		if _, err := puzzleNumber(settings.date, settings.epoch); err != nil {
+			settings.errMsg = err.Error()
+		}
+	}
+	if len(settings.errMsg) > 0 {
+		return settings
+	}
+	if n, err := strconv.ParseInt(seed, 10, 64); err == nil {
+		settings.seed = n
+	} else {
+		settings.seedText = seed
+		settings.seed = int64(hashSeed(seed))
+	}
+	if len(settings.scenarioFile) > 0 {
+		run = true
+	}
+	numModes := 0
+	for _, selected := range []bool{run, guess, len(settings.replayFile) > 0, settings.numHardest > 0,
+		settings.numSelfCheck > 0, len(settings.makeOpeningsFile) > 0, benchmarkMode, compareMode, findMode,
+		analyzeMode, len(settings.shareFile) > 0, len(settings.rateWord) > 0, len(distinguishWords) > 0,
+		len(settings.suggestFile) > 0, testMode, proveMode, len(settings.uniquenessWord) > 0,
+		len(settings.treeFile) > 0, len(diffLists) > 0, makeShare} {
+		if selected {
+			numModes++
+		}
+	}
+	if numModes != 1 {
+		settings.errMsg = "You must specify exactly one of --guess, --run, --replay, --hardest,\n--selfcheck, --make-openings, --benchmark, --compare, --find, --analyze, --from-share,\n--rate, --suggest-from, --test, --prove-solvable, --distinguish, --uniqueness,\n--export-tree, --diff-lists or --make-share"
+	} else if settings.boards < 1 {
+		settings.errMsg = "--boards must be at least 1"
+	} else if settings.tint && !settings.showBoard {
+		settings.errMsg = "--tint requires --board"
+	} else if settings.width < 0 {
+		settings.errMsg = "--width must not be negative"
+	} else if len(settings.target) > 0 && (!guess || settings.boards != 1) {
+		settings.errMsg = "--target requires --guess with a single board"
+	} else if len(settings.target) > 0 && utf8.RuneCountInString(settings.target) != LETTERS_IN_WORD {
+		settings.errMsg = fmt.Sprintf("--target must be %v letters long", LETTERS_IN_WORD)
+	} else if settings.finalOnly && len(settings.target) == 0 {
+		settings.errMsg = "--final-only requires --target"
+	} else if len(settings.injections) > 0 && len(settings.target) == 0 {
+		settings.errMsg = "--inject requires --target"
+	} else if !isStrategy(settings.strategy) {
+		settings.errMsg = "--strategy must be first, minimax, entropy or lookahead"
+	} else if settings.lookaheadWidth < 1 {
+		settings.errMsg = "--lookahead-k must be at least 1"
+	} else if !isTiebreak(settings.tiebreak) {
+		settings.errMsg = "--tiebreak must be list, common or alpha"
+	} else if settings.order != "list" && settings.order != "alpha" && settings.order != "random" {
+		settings.errMsg = "--order must be list, alpha or random"
+	} else if settings.repeats != "notice" && settings.repeats != "confirm" && settings.repeats != "allow" {
+		settings.errMsg = "--repeats must be notice, confirm or allow"
+	} else if settings.blind && settings.coach {
+		settings.errMsg = "--blind cannot be used with --coach"
+	} else if !settings.strict && settings.freeInvalid > 0 {
+		settings.errMsg = "--free-invalid cannot be used with --strict=false"
+	} else if settings.categoryWeights != nil && (!run || len(settings.category) > 0) {
+		settings.errMsg = "--category-weights requires --run, and cannot be used with --category"
+	} else if settings.explore && !run {
+		settings.errMsg = "--explore requires --run"
+	} else if settings.practice && !run {
+		settings.errMsg = "--practice requires --run"
+	} else if len(settings.ttsCommand) > 0 && !run {
+		settings.errMsg = "--tts-command requires --run"
+	} else if settings.strictSecret && !run {
+		settings.errMsg = "--strict-secret requires --run"
+	} else if settings.noPlurals && !run {
+		settings.errMsg = "--no-plurals requires --run"
+	} else if len(settings.locked) > 0 && (!run || utf8.RuneCountInString(settings.locked) != LETTERS_IN_WORD) {
+		settings.errMsg = fmt.Sprintf("--locked requires --run, and must be %v letters long", LETTERS_IN_WORD)
+	} else if len(settings.alsoAccepted) > 0 && !run {
+		settings.errMsg = "only --run accepts more than one --word"
+	} else if len(settings.scenarioFile) > 0 && (len(settings.word) > 0 || settings.askSecret || settings.timeLimit > 0) {
+		settings.errMsg = "--scenario cannot be used with --word, --ask-secret or --challenge"
+	} else if settings.again && (!run || len(settings.word) > 0 || len(settings.scenarioFile) > 0) {
+		settings.errMsg = "--again requires --run, and cannot be used with --word or --scenario"
+	} else if settings.askSecret && (!run || len(settings.word) > 0) {
+		settings.errMsg = "--ask-secret requires --run, and cannot be used with --word"
+	} else if (settings.coach && !run) || ((settings.coachAnagrams || settings.coachUseless) && !settings.coach) {
+		settings.errMsg = "--coach requires --run, and --coach-anagrams and --coach-useless require --coach"
 	} else {
 		if run {
 			settings.runType = RUN
-		} else {
+		} else if guess {
 			settings.runType = GUESS
+		} else if settings.numHardest > 0 {
+			settings.runType = HARDEST
+		} else if settings.numSelfCheck > 0 {
+			settings.runType = SELFCHECK
+		} else if len(settings.makeOpeningsFile) > 0 {
+			settings.runType = MAKE_OPENINGS
+		} else if benchmarkMode {
+			settings.runType = BENCHMARK
+		} else if compareMode {
+			settings.runType = COMPARE
+		} else if findMode {
+			settings.runType = FIND
+		} else if analyzeMode {
+			settings.runType = ANALYZE
+		} else if testMode {
+			settings.runType = TEST
+		} else if makeShare {
+			settings.runType = MAKE_SHARE
+			if len(settings.word) == 0 || len(settings.guesses) == 0 {
+				settings.errMsg = "--make-share requires --word and --guesses"
+			} else if checkGuessLength(settings.word) != nil {
+				settings.errMsg = fmt.Sprintf("--word must be %v letters long", LETTERS_IN_WORD)
+			}
+		} else if len(diffLists) > 0 {
+			settings.runType = DIFF_LISTS
+			settings.diffLists = strings.Split(diffLists, ",")
+			if len(settings.diffLists) != 2 {
+				settings.errMsg = "--diff-lists must be two word lists separated by a comma"
+			}
+		} else if len(settings.treeFile) > 0 {
+			settings.runType = EXPORT_TREE
+		} else if proveMode {
+			settings.runType = PROVE_SOLVABLE
+		} else if len(settings.suggestFile) > 0 {
+			settings.runType = SUGGEST
+		} else if len(distinguishWords) > 0 {
+			settings.runType = DISTINGUISH
+			settings.distinguish = strings.Split(strings.ToLower(distinguishWords), ",")
+			if len(settings.distinguish) != 2 || settings.distinguish[0] == settings.distinguish[1] {
+				settings.errMsg = "--distinguish must be two different words separated by a comma"
+			} else if checkGuessLength(settings.distinguish[0]) != nil || checkGuessLength(settings.distinguish[1]) != nil {
+				settings.errMsg = fmt.Sprintf("--distinguish words must be %v letters long", LETTERS_IN_WORD)
+			}
+		} else if len(settings.uniquenessWord) > 0 {
+			settings.runType = UNIQUENESS
+			settings.uniquenessWord = strings.ToLower(settings.uniquenessWord)
+			if err := checkGuessLength(settings.uniquenessWord); err != nil {
+				settings.errMsg = fmt.Sprintf("--uniqueness word must be %v letters long", LETTERS_IN_WORD)
+			}
+		} else if len(settings.rateWord) > 0 {
+			settings.runType = RATE
+		} else if len(settings.shareFile) > 0 {
+			settings.runType = FROM_SHARE
+			if len(settings.guesses) == 0 {
+				settings.errMsg = "--from-share requires --guesses"
+			}
+			for _, guess := range settings.guesses {
+				if utf8.RuneCountInString(guess) != LETTERS_IN_WORD {
+					settings.errMsg = fmt.Sprintf("--guesses must each be %v letters long", LETTERS_IN_WORD)
+				}
+			}
+		} else {
+			settings.runType = REPLAY
+			if len(settings.word) == 0 {
+				settings.errMsg = "--replay requires --word"
+			} else if utf8.RuneCountInString(settings.word) != LETTERS_IN_WORD {
+				settings.errMsg = fmt.Sprintf("--word must be %v letters long", LETTERS_IN_WORD)
+			}
 		}
 	}
 	return settings
 }
 
+// Return a hash of a --seed given as text.  It must never change, so that
+// a seed always gives the same word.
+func hashSeed(text string) uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(text))
+	return hash.Sum64()
+}
+
+// Rearrange AllWords into the given order (see --order).  The solver
+// guesses the first word that matches the clues, so this decides which
+// of several matching words it picks.
+func applyWordOrder(order string) {
+	words := make([]string, len(AllWords))
+	copy(words, AllWords)
+	if order == "alpha" {
+		sort.Strings(words)
+	} else if order == "random" {
+		rng.Shuffle(len(words), func(i, j int) {
+			words[i], words[j] = words[j], words[i]
+		})
+	}
+	AllWords = words
+}
+
 func isKnownWord(word string) bool {
 	found := false
 	for _, knownWord := range AllWords {
@@ -97,77 +1083,492 @@ func isKnownWord(word string) bool {
 	return found
 }
 
-func readGuessResult() string {
-	MyScanner.Scan()
-	response := MyScanner.Text()
-	return response
+// The letter --wildcards lets a player put in a guess for an unknown slot.
+const WILDCARD = '*'
+
+// Return the response (made of y, p and n) that Side 1 would give for
+// guess when the word being guessed is word.  The words are compared
+// letter by letter (rune by rune), so letters need not be ASCII.
+// A WILDCARD in the guess is always marked n.
+func evaluateGuess(guess string, word string) string {
+	guessLetters := []rune(guess)
+	wordLetters := []rune(word)
+	response := make([]string, len(guessLetters))
+	// First, scan for the correct letters in the correct places.
+	// We need to have this information to later determine whether
+	// a given letter that matches a letter in a different position
+	// is a "p" or "n".
+	for j := 0; j < len(guessLetters); j++ {
+		if guessLetters[j] == wordLetters[j] && guessLetters[j] != WILDCARD {
+			response[j] = "y"
+		}
+	}
+	// Count the letters of the word that are not correctly guessed.  Each
+	// can account for one p, so that a letter guessed twice is only
+	// marked p twice if the word has two more copies of it.
+	unmatched := make(map[rune]int)
+	for j := 0; j < len(wordLetters); j++ {
+		if response[j] != "y" {
+			unmatched[wordLetters[j]]++
+		}
+	}
+	for j := 0; j < len(guessLetters); j++ {
+		guessCh := guessLetters[j]
+		if guessCh == WILDCARD {
+			response[j] = "n"
+		} else if response[j] != "y" {
+			if unmatched[guessCh] > 0 {
+				unmatched[guessCh]--
+				response[j] = "p"
+			} else {
+				response[j] = "n"
+			}
+		}
+	}
+	return strings.Join(response, "")
+}
+
+// What typing help or ? at the Guess: prompt shows.
+var RUN_HELP = []string{
+	"Type a word to guess it. The result has a letter for each letter of it:",
+	"  y  the letter is in the word, in that spot",
+	"  p  the letter is in the word, but in another spot",
+	"  n  the letter is not in the word (or not as many times as you guessed it)",
+	"Instead of a guess you can type:",
+	"  coverage word  show which letters of word you haven't tried yet",
+	"  help or ?      show this message",
+	"  q              give up, and see the word",
+}
+
+func printRunHelp() {
+	for _, line := range RUN_HELP {
+		fmt.Println(line)
+	}
+}
+
+// Report whether guess is one of the other words accepted as the answer.
+func isAlsoAccepted(guess string, alsoAccepted []string) bool {
+	for _, word := range alsoAccepted {
+		if guess == word {
+			return true
+		}
+	}
+	return false
 }
 
-func runGame(word string) {
-	if len(word) == 0 {
-		word = AllWords[rand.Intn(len(AllWords))]
+// Return word, followed by the other words that were accepted, if any,
+// for the message at the end of a game.
+func describeAnswer(word string, alsoAccepted []string) string {
+	if len(alsoAccepted) == 0 {
+		return word
+	}
+	return fmt.Sprintf("%v (also accepted: %v)", word, strings.Join(alsoAccepted, ", "))
+}
+
+// Play a game in run mode.  Return whether the player found the word, and
+// the number of guesses made.  The error is for an --image or --log file
+// that could not be written; the game stands either way.
+func runGame(settings Settings) (bool, int, error) {
+	word := settings.word
+	var coach *Coach
+	if settings.coach {
+		coach = NewCoach(settings.coachAnagrams, settings.coachUseless)
+	}
+	answers := answerPool(settings.noPlurals, settings.locked)
+	if len(word) == 0 && settings.categoryWeights != nil {
+		word = chooseWeightedWord(answers, settings.categoryWeights)
+	} else if len(word) == 0 {
+		word = chooseWord(answers)
 	}
 	//fmt.Println("The word is " + word)
+	currentSecret.Store(word)
+	defer currentSecret.Store("")
+	if category, present := wordCategories[word]; present {
+		fmt.Println("Category: " + category)
+	}
+	for _, hint := range settings.hints {
+		giveHint(hint, word)
+	}
+	// Number of letters marked y or p in the previous guess, or -1 before
+	// the first guess.
+	prevMarked := -1
+	guessesMade := make(StringSet)
+	// Which positions of the word the player knows, from greens or
+	// letters revealed by --teach.
+	knownPositions := make([]bool, LETTERS_IN_WORD)
+	if len(settings.locked) > 0 {
+		knownPositions = lockedPositions(settings.locked)
+		fmt.Println("Known:  " + formatKnownLetters(word, knownPositions))
+	}
+	numFailed := 0
+	// The letters used in any guess, for the summary at the end.
+	triedLetters := make(StringSet)
+	if settings.coach || settings.verbose {
+		defer printUntriedLetters(triedLetters)
+	}
+	if settings.timeLimit > 0 {
+		startInputReader()
+	}
+	numGuesses := 0
+	// The response to each guess, for --share.
+	var responses []string
+	// Each guess and its response, for --explore.
+	var history []TranscriptEntry
+	// The number of guesses that were not in the word list.
+	numInvalid := 0
+	// When the time for the current guess runs out, in challenge mode.
+	var deadline time.Time
+	solved := false
+	// How the game ended, and when it started, for --log.
+	outcome := "quit"
+	start := time.Now()
+	// Whether the player ran out of guesses and, with --practice, is
+	// still guessing.
+	overtime := false
+	// The --tts-command, or "" once it has failed.
+	ttsCommand := settings.ttsCommand
 	for running := true; running; {
-		fmt.Print(" Guess: ")
-		MyScanner.Scan()
-		guess := MyScanner.Text()
-		if "q" == guess {
-			fmt.Println("The word was " + word)
+		if settings.maxGuesses > 0 && numGuesses >= settings.maxGuesses && !overtime {
+			outcome = "lost"
+			if settings.share {
+				printShare(settings, responses, false, numGuesses)
+			}
+			if !settings.practice {
+				fmt.Println("Out of guesses. The word was " + describeAnswer(word, settings.alsoAccepted))
+				break
+			}
+			fmt.Println("Out of guesses. Keep guessing in overtime, or type q to see the word.")
+			overtime = true
+		}
+		if settings.timeLimit > 0 {
+			if deadline.IsZero() {
+				deadline = time.Now().Add(time.Duration(settings.timeLimit) * time.Second)
+			}
+			secondsLeft := int(math.Ceil(time.Until(deadline).Seconds()))
+			fmt.Printf(" Guess (%vs left): ", secondsLeft)
+		} else {
+			fmt.Print(" Guess: ")
+		}
+		guess, ok, timedOut := readLineBefore(deadline)
+		guess = fillLocked(guess, settings.locked)
+		if timedOut {
+			fmt.Println()
+			fmt.Println("Time's up! That counts as a guess.")
+			numGuesses++
+			deadline = time.Time{}
+		} else if "q" == guess || !ok {
+			fmt.Println("The word was " + describeAnswer(word, settings.alsoAccepted))
 			break
-		} else if len(guess) != 5 {
-			fmt.Println("Guesses must be exactly 5 lowercase letters")
+		} else if guess == "help" || guess == "?" {
+			printRunHelp()
+		} else if fields := strings.Fields(guess); len(fields) == 2 && fields[0] == "coverage" {
+			printCoverage(fields[1], triedLetters)
+		} else if err := checkGuessLength(guess); err != nil {
+			fmt.Println(err)
+		} else if conflict := lockedConflict(guess, settings.locked); len(conflict) > 0 {
+			fmt.Printf("%v changes a locked letter: %v\n", guess, conflict)
+		} else if strings.ContainsRune(guess, WILDCARD) && !settings.wildcards {
+			fmt.Printf("%v has a %c, which is only allowed with --wildcards\n", guess, WILDCARD)
+		} else if strings.Count(guess, string(WILDCARD)) > 1 {
+			fmt.Printf("%v has more than one %c\n", guess, WILDCARD)
+		} else if !inAlphabet(strings.ReplaceAll(guess, string(WILDCARD), "")) {
+			fmt.Printf("%v has letters that are not in the alphabet %v\n", guess, alphabet)
 		} else {
-			// The guess must be a known word
-			if !isKnownWord(guess) {
-				fmt.Println(guess + " is not a valid word")
+			// Unless --strict=false, the guess must be a known word.  A
+			// guess with a wildcard can't be looked up, so it is exempt.
+			if err := checkKnownWord(guess); settings.strict && !strings.ContainsRune(guess, WILDCARD) && err != nil {
+				numInvalid++
+				if settings.freeInvalid > 0 && numInvalid > settings.freeInvalid {
+					fmt.Printf("%v. That counts as a guess.\n", err)
+					numGuesses++
+				} else {
+					fmt.Println(err)
+				}
+			} else if guessesMade.Contains(guess) && !acceptRepeat(settings.repeats, guess) {
+				continue
 			} else {
-				response := [5]string{" ", " ", " ", " ", " "}
-				// First, scan for the correct letters in the correct places.
-				// We need to have this information to later determine whether
-				// a given letter that matches a letter in a different position
-				// is a "p" or "n".
-				for j := 0; j < len(guess); j++ {
-					guessCh := guess[j : j+1]
-					//fmt.Println("Looking at char " + ch + " " + response)
-					wordCh := word[j : j+1]
-					if guessCh == wordCh {
-						response[j] = "y"
+				numGuesses++
+				deadline = time.Time{}
+				guessesMade.Add(guess)
+				for _, letter := range guess {
+					triedLetters.Add(string(letter))
+				}
+				responseStr := evaluateGuess(guess, word)
+				if isAlsoAccepted(guess, settings.alsoAccepted) {
+					fmt.Printf("%v is also accepted; the word was %v\n", guess, word)
+					responseStr = strings.Repeat("y", LETTERS_IN_WORD)
+				}
+				shownResponse := responseStr
+				if settings.blind {
+					shownResponse = maskResponse(responseStr)
+				}
+				warmth := ""
+				if settings.warmer {
+					marked := strings.Count(shownResponse, "y") + strings.Count(shownResponse, "p")
+					if prevMarked >= 0 && marked > prevMarked {
+						warmth = " (warmer)"
+					} else if prevMarked >= 0 && marked < prevMarked {
+						warmth = " (colder)"
 					}
+					prevMarked = marked
 				}
-				for j := 0; j < len(guess); j++ {
-					guessCh := guess[j : j+1]
-					//fmt.Println("Looking at char " + ch + " " + response)
-					wordCh := word[j : j+1]
-					if guessCh != wordCh {
-						// Iterate through the correct word, to see if this char
-						// is found elsewhere in the word.
-						found := false
-						for k := 0; k < len(word); k++ {
-							if k != j {
-								if guessCh == word[k:k+1] && response[k] != "y" {
-									// The guessed char is in the word, and not at
-									// a position that is a correct guess.
-									found = true
-								}
-							}
-						}
-						if found {
-							response[j] = "p"
-						} else {
-							response[j] = "n"
-						}
+				note := warmth
+				if overtime {
+					note += " (overtime)"
+				}
+				if settings.symbols {
+					fmt.Println("Result: " + formatWithSymbols(guess, shownResponse) + note)
+				} else {
+					fmt.Println("Result: " + shownResponse + note)
+				}
+				if len(ttsCommand) > 0 {
+					if err := speak(ttsCommand, describeResult(guess, shownResponse)); err != nil {
+						fmt.Println("Warning: --tts-command failed, so results won't be read aloud: " + err.Error())
+						ttsCommand = ""
 					}
 				}
-				responseStr := strings.Join(response[:], "")
-				fmt.Println("Result: " + responseStr)
-				if responseStr == "yyyyy" {
+				if coach != nil {
+					coach.reviewGuess(guess, responseStr)
+				}
+				history = append(history, TranscriptEntry{guess: guess, response: responseStr})
+				for j := 0; j < len(responseStr); j++ {
+					if responseStr[j:j+1] == "y" {
+						knownPositions[j] = true
+					}
+				}
+				responses = append(responses, responseStr)
+				if responseStr == "yyyyy" && overtime {
+					fmt.Printf("You found it in overtime, after %v guesses.\n", numGuesses)
+					running = false
+				} else if responseStr == "yyyyy" {
 					fmt.Println("Congratulations!")
+					solved = true
+					outcome = "won"
+					if settings.share {
+						printShare(settings, responses, true, numGuesses)
+					}
+					if settings.explore {
+						offerOtherAnswers(history, word)
+					}
 					running = false
+				} else {
+					if settings.teach > 0 {
+						numFailed++
+						if numFailed%settings.teach == 0 {
+							revealLetter(knownPositions)
+						}
+					}
+					if settings.teach > 0 || settings.revealGreens {
+						fmt.Println("Known:  " + formatKnownLetters(word, knownPositions))
+					}
 				}
 			}
 		}
 	}
+	var imageErr error
+	if len(settings.imageFile) > 0 && outcome != "quit" {
+		if err := writeGridImage(settings.imageFile, responses); err != nil {
+			imageErr = fmt.Errorf("Cannot write %v: %v", settings.imageFile, err)
+		}
+	}
+	logErr := logGame(settings.logFile, newGameRecord("run", word, history, outcome, numGuesses, start))
+	return solved, numGuesses, errors.Join(imageErr, logErr)
+}
+
+// The games played in run mode with --again.  Only the games won count
+// toward the average number of guesses.
+type SessionTally struct {
+	numGames     int
+	numWon       int
+	guessesInWon int
+}
+
+func (tally *SessionTally) add(solved bool, numGuesses int) {
+	tally.numGames++
+	if solved {
+		tally.numWon++
+		tally.guessesInWon += numGuesses
+	}
+}
+
+func (tally *SessionTally) print() {
+	fmt.Printf("This session: won %v of %v games", tally.numWon, tally.numGames)
+	if tally.numWon > 0 {
+		fmt.Printf(", averaging %.2f guesses", float64(tally.guessesInWon)/float64(tally.numWon))
+	}
+	fmt.Println()
+}
+
+// Ask whether to play another game.  Return false at the end of input.
+func playAgain() bool {
+	fmt.Print("Play again? (y/n) ")
+	answer, ok := readLine()
+	return ok && strings.HasPrefix(strings.ToLower(answer), "y")
+}
+
+// The most other answers --explore lists.
+const MAX_OTHER_ANSWERS_SHOWN = 50
+
+// Return the words other than word that would have given the same
+// response to every guess in history.
+func otherAnswers(history []TranscriptEntry, word string) []string {
+	var others []string
+	for _, candidate := range wordsFitting(history) {
+		if candidate != word {
+			others = append(others, candidate)
+		}
+	}
+	return others
+}
+
+// After the player finds word, ask whether to list the other words that
+// fit the results of the guesses before it, and list them if so.
+func offerOtherAnswers(history []TranscriptEntry, word string) {
+	fmt.Print("Show other possible answers? (y/n) ")
+	answer, _ := readLine()
+	if !strings.HasPrefix(strings.ToLower(answer), "y") {
+		return
+	}
+	// The last guess was word itself, which only word fits.
+	others := otherAnswers(history[:len(history)-1], word)
+	if len(others) == 0 {
+		fmt.Println("No other word fit your clues")
+	} else if len(others) > MAX_OTHER_ANSWERS_SHOWN {
+		fmt.Printf("%v other words fit your clues, including: %v\n",
+			len(others), strings.Join(others[:MAX_OTHER_ANSWERS_SHOWN], " "))
+	} else {
+		fmt.Printf("%v other words fit your clues: %v\n", len(others), strings.Join(others, " "))
+	}
+}
+
+// Print the share block for a game that is over, in which numGuesses
+// guesses counted.
+func printShare(settings Settings, responses []string, solved bool, numGuesses int) {
+	// This was checked when the command line was parsed.
+	number, _ := puzzleNumber(settings.date, settings.epoch)
+	maxGuesses := settings.maxGuesses
+	if maxGuesses == 0 {
+		maxGuesses = MAX_GUESSES
+	}
+	fmt.Println()
+	fmt.Println(formatShare(number, responses, solved, numGuesses, maxGuesses))
+}
+
+// Say how many letters of word the player hasn't tried yet, and which,
+// for the coverage command.
+func printCoverage(word string, triedLetters StringSet) {
+	newLetters := make(StringSet)
+	var list []string
+	for _, letter := range word {
+		if !triedLetters.Contains(string(letter)) && !newLetters.Contains(string(letter)) {
+			newLetters.Add(string(letter))
+			list = append(list, string(letter))
+		}
+	}
+	if len(list) == 0 {
+		fmt.Println(word + " would test no new letters")
+	} else {
+		fmt.Printf("%v would test %v new letters: %v\n", word, len(list), strings.Join(list, " "))
+	}
+}
+
+// At the end of a game, list the letters of the alphabet the player
+// never tried.
+func printUntriedLetters(triedLetters StringSet) {
+	untried := ""
+	for _, letter := range alphabet {
+		if !triedLetters.Contains(string(letter)) {
+			untried += string(letter)
+		}
+	}
+	if len(untried) == 0 {
+		fmt.Println("You tried every letter")
+	} else {
+		fmt.Println("Letters you never tried: " + untried)
+	}
+}
+
+// The symbol used by --symbols for each response character.  - is the
+// mark --blind uses for anything that isn't green.
+var responseSymbols = map[string]string{"y": "✓", "p": "~", "n": "✗", "-": "?"}
+
+// Return the letters of guess, each preceded by the symbol for its
+// response, like "✓c ~r ✗a ✗n ✓e".
+func formatWithSymbols(guess string, response string) string {
+	var marked []string
+	for j, letter := range []rune(guess) {
+		marked = append(marked, responseSymbols[response[j:j+1]]+string(letter))
+	}
+	return strings.Join(marked, " ")
+}
+
+// Return response with everything but the greens (y) replaced by -,
+// for --blind.
+func maskResponse(response string) string {
+	masked := ""
+	for j := 0; j < len(response); j++ {
+		if response[j:j+1] == "y" {
+			masked += "y"
+		} else {
+			masked += "-"
+		}
+	}
+	return masked
+}
+
+// Mark the first position the player doesn't know yet as known.
+func revealLetter(knownPositions []bool) {
+	for j := range knownPositions {
+		if !knownPositions[j] {
+			knownPositions[j] = true
+			return
+		}
+	}
+}
+
+// Return word with the letters the player doesn't know yet shown as _,
+// like "c _ a _ e".
+func formatKnownLetters(word string, knownPositions []bool) string {
+	letters := []rune(word)
+	shown := make([]string, len(letters))
+	for j, letter := range letters {
+		if knownPositions[j] {
+			shown[j] = string(letter)
+		} else {
+			shown[j] = "_"
+		}
+	}
+	return strings.Join(shown, " ")
+}
+
+// Called when guess has already been made this game.  Depending on the
+// --repeats setting, tell the player and perhaps ask whether to go ahead.
+// Return true if the guess should be accepted.
+func acceptRepeat(repeats string, guess string) bool {
+	if repeats == "notice" {
+		fmt.Println("You already guessed " + guess)
+	} else if repeats == "confirm" {
+		fmt.Print("You already guessed " + guess + "; guess anyway? (y/n) ")
+		answer, _ := readLine()
+		return strings.HasPrefix(strings.ToLower(answer), "y")
+	}
+	return true
+}
+
+// Tell the player something about word before they start guessing.
+// hint is "vowels" or "distinct".
+func giveHint(hint string, word string) {
+	if hint == "vowels" {
+		numVowels := 0
+		for _, letter := range word {
+			if strings.ContainsRune(VOWELS, letter) {
+				numVowels++
+			}
+		}
+		fmt.Printf("Hint: the word has %v vowels\n", numVowels)
+	} else if hint == "distinct" {
+		fmt.Printf("Hint: the word has %v distinct letters\n", len(makeMapFromWord(word)))
+	}
 }
 
 // Define a Set type as a map with a boolean value
@@ -193,21 +1594,110 @@ func (set StringSet) Contains(element string) bool {
 	return set[element]
 }
 
-// Return true if we found the correct word.
-func processResponse(validLetters *[LETTERS_IN_WORD]StringSet, myGuess string,
-	response string) bool {
+// Solver holds what we have learned so far about one word we are trying
+// to guess.
+type Solver struct {
+	// An array of sets, one for each position in the word being guessed.
+	// Each set holds the letters that could still be in that position.
+	validLetters [LETTERS_IN_WORD]StringSet
+	// Map: index is a letter, value is the minimum number of occurrences of that
+	// letter in the word we are trying to guess.  We don't populate with letters
+	// that we don't yet know are required.
+	requiredLetters map[string]int
+	// Map: index is a letter, value is the maximum number of occurrences of
+	// that letter.  It is only known when a guess repeats a letter and the
+	// response marks some copies y or p and others n.
+	maxLetters map[string]int
+	// The guesses made so far, with the response to each.
+	history []TranscriptEntry
+	// True once we have been told that a guess is the word.
+	solved bool
+	// The word that was found, once solved is true.
+	answer string
+	// The clues given with the no and yes commands, in order.
+	manual []ManualClue
+}
+
+// A clue given with the no or yes command, rather than by a response.
+type ManualClue struct {
+	// The number of guesses made when it was given.
+	afterGuesses int
+	letter       string
+	// How many of the letter the word has at least, or 0 if none.
+	count int
+}
+
+// Apply clue to the clues, which must already have been checked.
+func (solver *Solver) applyManualClue(clue ManualClue) {
+	if clue.count == 0 {
+		for idx := range solver.validLetters {
+			solver.validLetters[idx].Remove(clue.letter)
+		}
+	} else if clue.count > solver.requiredLetters[clue.letter] {
+		solver.requiredLetters[clue.letter] = clue.count
+	}
+}
+
+// Create a Solver that knows nothing yet: every letter is possible in
+// every position.
+func NewSolver() *Solver {
+	solver := &Solver{requiredLetters: make(map[string]int), maxLetters: make(map[string]int)}
+	for idx := 0; idx < len(solver.validLetters); idx++ {
+		solver.validLetters[idx] = make(StringSet)
+		for _, letter := range alphabet {
+			solver.validLetters[idx].Add(string(letter))
+		}
+	}
+	return solver
+}
+
+// Apply the response to myGuess to the clues.  Return true if we found
+// the correct word.  A guess of the wrong length, a malformed response,
+// or a yyyyy for a word that can't be the answer, returns an error and
+// leaves the clues as they were.
+func (solver *Solver) processResponse(myGuess string, response string) (bool, error) {
+	validLetters := &solver.validLetters
 	foundAnswer := false
-	if response == "yyyyy" {
+	if err := checkGuessLength(myGuess); err != nil {
+		return false, err
+	}
+	if err := checkResponse(response); err != nil {
+		return false, err
+	}
+	if response == "yyyyy" && !solver.matchesClues(myGuess) {
+		// Most likely the response was mistyped.  Don't stop on a word
+		// that can't be the answer.
+		return false, inputError(ErrInvalidResponse, "%v can't be the answer: %v. Please check the response.",
+			myGuess, solver.explainMismatch(myGuess))
+	} else if response == "yyyyy" {
 		foundAnswer = true
-	} else if len(response) != LETTERS_IN_WORD {
-		fmt.Printf("Response must be of length %v\n", LETTERS_IN_WORD)
+		solver.solved = true
+		solver.answer = myGuess
+		solver.history = append(solver.history, TranscriptEntry{guess: myGuess, response: response})
 	} else {
+		solver.history = append(solver.history, TranscriptEntry{guess: myGuess, response: response})
+		// Note the letters marked y or p anywhere in this guess.  An n for
+		// a repeat of one of those letters only means the word has no more
+		// copies of it, so it rules the letter out of that position alone.
+		guessLetters := []rune(myGuess)
+		markedThisGuess := make(StringSet)
+		for ipos := 0; ipos < LETTERS_IN_WORD; ipos++ {
+			if response[ipos:ipos+1] != "n" {
+				markedThisGuess.Add(string(guessLetters[ipos]))
+			}
+		}
 		// Loop through the letters in the response.
 		var charToCountThisGuess map[string]int = make(map[string]int)
+		// The letters that also had an n, so that the word has exactly as
+		// many of them as were marked y or p.
+		cappedThisGuess := make(StringSet)
 		for ipos := 0; ipos < LETTERS_IN_WORD; ipos++ {
 			respCh := response[ipos : ipos+1]
-			guessCh := myGuess[ipos : ipos+1]
-			if respCh == "n" {
+			guessCh := string(guessLetters[ipos])
+			if respCh == "n" && markedThisGuess.Contains(guessCh) {
+				validLetters[ipos].Remove(guessCh)
+				cappedThisGuess.Add(guessCh)
+			} else if respCh == "n" {
 				for j := 0; j < LETTERS_IN_WORD; j++ {
 					validLetters[j].Remove(guessCh)
 				}
@@ -230,36 +1720,37 @@ func processResponse(validLetters *[LETTERS_IN_WORD]StringSet, myGuess string,
 				} else {
 					charToCountThisGuess[guessCh] = 1
 				}
-			} else {
-				fmt.Println("Unexpected response char: " + respCh)
 			}
 		}
 		// Now we have accumulated in charToCountThisGuess the info from the response
 		// for this guessed word. Apply this knowledge to requiredLetters, which will
 		// reflect required letters info from all responses so far.
 		for requiredCh, count := range charToCountThisGuess {
-			oldCount, present := requiredLetters[requiredCh]
+			oldCount, present := solver.requiredLetters[requiredCh]
 			if present {
 				if count > oldCount {
-					requiredLetters[requiredCh] = count
+					solver.requiredLetters[requiredCh] = count
 				}
 			} else {
-				requiredLetters[requiredCh] = count
+				solver.requiredLetters[requiredCh] = count
 			}
 		}
+		for cappedCh := range cappedThisGuess {
+			solver.maxLetters[cappedCh] = charToCountThisGuess[cappedCh]
+		}
 	}
-	return foundAnswer
+	return foundAnswer, nil
 }
 
-func printSetOfValidLetters(validLetters *[LETTERS_IN_WORD]StringSet) {
+func (solver *Solver) printSetOfValidLetters() {
 	// Debug print the set of valid letters for each position.
+	validLetters := &solver.validLetters
 	for k := 0; k < len(validLetters); k++ {
 		fmt.Print(k, " ")
 		msg := ""
-		for idx := 0; idx < 26; idx++ {
-			ch := "abcdefghijklmnopqrstuvwxyz"[idx : idx+1]
-			if validLetters[k][ch] {
-				msg += ch
+		for _, letter := range alphabet {
+			if validLetters[k][string(letter)] {
+				msg += string(letter)
 			}
 		}
 		fmt.Println(msg)
@@ -269,8 +1760,8 @@ func printSetOfValidLetters(validLetters *[LETTERS_IN_WORD]StringSet) {
 func makeMapFromWord(word string) map[string]int {
 	mapLetterToCount := make(map[string]int)
 
-	for j := 0; j < len(word); j++ {
-		ch := word[j : j+1]
+	for _, letter := range word {
+		ch := string(letter)
 		count, present := mapLetterToCount[ch]
 		if present {
 			mapLetterToCount[ch] = count + 1
@@ -282,83 +1773,1122 @@ func makeMapFromWord(word string) map[string]int {
 	return mapLetterToCount
 }
 
-func doGuesses() {
-	// Define an array of sets, one for each position in the word being guessed.
-	// Initially populate each set with all possible letters.
+// Return true if word is compatible with the clues we have so far.
+func (solver *Solver) matchesClues(word string) bool {
+	// Loop through the letters of this word.  A letter may be more than
+	// one byte, so count positions separately.
+	ilet := 0
+	for _, letter := range word {
+		if !solver.validLetters[ilet].Contains(string(letter)) {
+			return false
+		}
+		ilet++
+	}
+	// The word matches according to validLetters, but does it have
+	// all the letters we know are in the word?
+	mapLetterToCountThisWord := makeMapFromWord(word)
+	for letter, numRequired := range solver.requiredLetters {
+		countThisWord, present := mapLetterToCountThisWord[letter]
+		if !present {
+			return false
+		} else if countThisWord < numRequired {
+			return false
+		}
+	}
+	for letter, maxCount := range solver.maxLetters {
+		if mapLetterToCountThisWord[letter] > maxCount {
+			return false
+		}
+	}
+	return true
+}
+
+// Return a description of the first clue that word does not fit, or ""
+// if it fits them all.  This makes the same checks as matchesClues, but
+// is slower since it says why.
+func (solver *Solver) explainMismatch(word string) string {
+	if utf8.RuneCountInString(word) != LETTERS_IN_WORD {
+		return fmt.Sprintf("it is not %v letters long", LETTERS_IN_WORD)
+	}
+	for ilet, letter := range []rune(word) {
+		ch := string(letter)
+		if !solver.validLetters[ilet].Contains(ch) {
+			return fmt.Sprintf("%v is not possible in position %v", ch, ilet+1)
+		}
+	}
+	mapLetterToCountThisWord := makeMapFromWord(word)
+	for letter, numRequired := range solver.requiredLetters {
+		if mapLetterToCountThisWord[letter] < numRequired {
+			return fmt.Sprintf("it needs at least %v %v", numRequired, letter)
+		}
+	}
+	for letter, maxCount := range solver.maxLetters {
+		if mapLetterToCountThisWord[letter] > maxCount {
+			return fmt.Sprintf("it can have at most %v %v", maxCount, letter)
+		}
+	}
+	return ""
+}
+
+// Report whether word fits the clues so far, and if not, why not.
+func (solver *Solver) checkWord(word string) {
+	reason := solver.explainMismatch(word)
+	if len(reason) == 0 {
+		fmt.Println(word + " fits the clues")
+	} else {
+		fmt.Println(word + " does not fit the clues: " + reason)
+	}
+	if !isKnownWord(word) {
+		fmt.Println(word + " is not in the word list")
+	}
+}
+
+// Check that letter is a single letter of the alphabet, for the
+// commands that take one.
+func checkLetter(letter string) error {
+	if utf8.RuneCountInString(letter) != 1 || !inAlphabet(letter) {
+		return fmt.Errorf("%v is not a letter of the alphabet %v", letter, alphabet)
+	}
+	return nil
+}
+
+// Rule letter out of every position, as if a guess had been marked n
+// for it, when the player knows from elsewhere that it isn't in the word.
+func (solver *Solver) excludeLetter(letter string) {
+	letter = strings.ToLower(letter)
+	if err := checkLetter(letter); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if solver.requiredLetters[letter] > 0 {
+		fmt.Printf("%v is already known to be in the word\n", letter)
+		return
+	}
+	clue := ManualClue{afterGuesses: len(solver.history), letter: letter}
+	solver.applyManualClue(clue)
+	solver.manual = append(solver.manual, clue)
+	fmt.Printf("Ruled out %v: %v\n", letter, formatCandidateCount(len(solver.findCandidates())))
+}
+
+// Require the word to have at least count copies of letter, somewhere,
+// when the player knows from elsewhere that it is in the word.
+func (solver *Solver) requireLetter(letter string, count int) {
+	letter = strings.ToLower(letter)
+	if err := checkLetter(letter); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if count < 1 || count > LETTERS_IN_WORD {
+		fmt.Printf("The count must be from 1 to %v\n", LETTERS_IN_WORD)
+		return
+	}
+	numPositions := 0
+	for _, valid := range solver.validLetters {
+		if valid.Contains(letter) {
+			numPositions++
+		}
+	}
+	if numPositions < count {
+		fmt.Printf("%v can only be in %v positions of the word\n", letter, numPositions)
+		return
+	}
+	if limit, capped := solver.maxLetters[letter]; capped && count > limit {
+		fmt.Printf("The word is known to have only %v of %v\n", limit, letter)
+		return
+	}
+	clue := ManualClue{afterGuesses: len(solver.history), letter: letter, count: count}
+	solver.applyManualClue(clue)
+	solver.manual = append(solver.manual, clue)
+	fmt.Printf("Required %v: %v\n", letter, formatCandidateCount(len(solver.findCandidates())))
+}
+
+// Report whether the solver has no clues yet, so that every word in the
+// list is a candidate.
+func (solver *Solver) knowsNothing() bool {
+	return len(solver.history) == 0 && len(solver.manual) == 0
+}
+
+// Return all words in AllWords that are compatible with the clues so far,
+// each once even if AllWords repeats it.
+func (solver *Solver) findCandidates() []string {
+	var candidates []string
+	seen := make(StringSet)
+	for _, word := range AllWords {
+		if solver.matchesClues(word) && !seen.Contains(word) {
+			seen.Add(word)
+			candidates = append(candidates, word)
+		}
+	}
+	return candidates
+}
+
+// If exactly one word fits the clues, return it; otherwise return "".
+func (solver *Solver) onlyCandidate() string {
+	answer := ""
+	for _, word := range AllWords {
+		if solver.matchesClues(word) && word != answer {
+			if len(answer) > 0 {
+				return ""
+			}
+			answer = word
+		}
+	}
+	return answer
+}
+
+// Return the word we should guess next, or "" if no word matches the clues.
+func (solver *Solver) chooseGuess() string {
+	guess, _ := solver.chooseGuessScanned()
+	return guess
+}
+
+// Like chooseGuess, but also return whether the guess is the first word
+// in the list to match the clues, found by firstMatch.
+func (solver *Solver) chooseGuessScanned() (guess string, scanned bool) {
+	if len(solver.history) < len(fixedGuesses) {
+		return fixedGuesses[len(solver.history)], false
+	}
+	if vowelsFirst && len(solver.history) < VOWEL_PROBES && !solver.solved {
+		if probe := solver.vowelProbe(); len(probe) > 0 {
+			return probe, false
+		}
+	}
+	if len(solver.history) == 1 {
+		first := solver.history[0]
+		if second, present := openings[openingKey(first.guess, first.response)]; present {
+			return second, false
+		}
+	}
+	if activeStrategy == "first" && !randomTies {
+		guess = solver.firstMatch()
+		scanned = len(guess) > 0
+	} else if candidates := solver.untriedCandidates(); len(candidates) > 0 {
+		guess = chooseByStrategy(candidates, solver.knowsNothing())
+	}
+	if probeUnknowns && len(guess) > 0 {
+		if probe := solver.chooseProbe(guess); probe != guess {
+			return probe, false
+		}
+	}
+	return guess, scanned
+}
+
+// Why the words before a guess in the list were passed over, for
+// --verbose with the first strategy.
+type ScanStats struct {
+	scanned        int
+	badPosition    int
+	missingLetter  int
+	tooManyLetters int
+	alreadyGuessed int
+}
+
+// Print how far into the list the first strategy looked to find guess,
+// which must be its choice, and why it passed over the words before it.  This makes the same
+// checks as matchesClues, one at a time.
+func (solver *Solver) printScan(guess string) {
+	var stats ScanStats
+	for _, word := range AllWords {
+		stats.scanned++
+		if word == guess {
+			break
+		}
+		letters := []rune(word)
+		badPosition := false
+		for ipos := range letters {
+			if !solver.validLetters[ipos].Contains(string(letters[ipos])) {
+				badPosition = true
+				break
+			}
+		}
+		counts := makeMapFromWord(word)
+		missingLetter := false
+		for letter, numRequired := range solver.requiredLetters {
+			if counts[letter] < numRequired {
+				missingLetter = true
+			}
+		}
+		tooManyLetters := false
+		for letter, maxCount := range solver.maxLetters {
+			if counts[letter] > maxCount {
+				tooManyLetters = true
+			}
+		}
+		if badPosition {
+			stats.badPosition++
+		} else if missingLetter {
+			stats.missingLetter++
+		} else if tooManyLetters {
+			stats.tooManyLetters++
+		} else {
+			stats.alreadyGuessed++
+		}
+	}
+	fmt.Printf("Scanned %v of %v words: %v ruled out by letter positions, %v missing required letters,"+
+		" %v with too many of a letter, %v already guessed\n", stats.scanned, len(AllWords),
+		stats.badPosition, stats.missingLetter, stats.tooManyLetters, stats.alreadyGuessed)
+}
+
+// Return the first word in the list that matches the clues we have so
+// far and hasn't been guessed.  There's no need to find all the
+// candidates.
+func (solver *Solver) firstMatch() string {
+	triedMatch := ""
+	for _, guess := range AllWords {
+		if solver.matchesClues(guess) {
+			if !solver.alreadyGuessed(guess) {
+				return guess
+			}
+			triedMatch = guess
+		}
+	}
+	if len(triedMatch) > 0 {
+		warnAlreadyGuessed(triedMatch)
+	}
+	return ""
+}
+
+// Called when a response ruled out every word (numAfter is 0) or none of
+// them, which usually means it was entered wrongly.  Ask whether it was
+// right, and return true if so.
+func confirmResponse(numAfter int) bool {
+	if numAfter == 0 {
+		fmt.Print("No word fits that response")
+	} else {
+		fmt.Print("That response didn't narrow anything")
+	}
+	fmt.Print(" — did you enter it correctly? (y/n) ")
+	answer, _ := readLine()
+	return !strings.HasPrefix(strings.ToLower(answer), "n")
+}
+
+// Print the guesses so far and their responses, one per line.
+// If tinted is set, each row also shows how many words were left after
+// it, and is brighter the more it narrowed them down.
+func (solver *Solver) printHistory(width int, tinted bool) {
+	var counts []int
+	if tinted {
+		counts = solver.candidateCounts()
+	}
+	numBefore := len(AllWords)
+	for i, entry := range solver.history {
+		line := fmt.Sprintf("  %v  %v", entry.guess, entry.response)
+		emoji := "  " + emojiRow(entry.response)
+		tint := ""
+		if tinted {
+			emoji += fmt.Sprintf("  (%v left)", counts[i])
+			tint = tintFor(numBefore, counts[i])
+			numBefore = counts[i]
+		}
+		if displayWidth(line+emoji) <= width {
+			printWrapped(line+emoji, width, tint)
+		} else {
+			// Put the emoji on a line of their own, and break any line
+			// that still doesn't fit.
+			printWrapped(line, width, tint)
+			printWrapped(emoji, width, tint)
+		}
+	}
+}
+
+// Return the number of words that fit the clues after each guess so far,
+// by applying the responses, and the clues from the no and yes commands,
+// again one at a time.
+func (solver *Solver) candidateCounts() []int {
+	replay := NewSolver()
+	var counts []int
+	// A clue given with the no or yes command counts toward the guess it
+	// followed, or the first guess if it came before any.
+	applyManual := func(afterGuesses int) {
+		for _, clue := range solver.manual {
+			if clue.afterGuesses == afterGuesses {
+				replay.applyManualClue(clue)
+			}
+		}
+	}
+	applyManual(0)
+	for i, entry := range solver.history {
+		replay.processResponse(entry.guess, entry.response)
+		applyManual(i + 1)
+		if replay.solved {
+			counts = append(counts, 1)
+		} else {
+			counts = append(counts, len(replay.findCandidates()))
+		}
+	}
+	return counts
+}
+
+// A guess that gives this many bits of information, or more, is shown
+// brightest by --tint.
+const MAX_TINT_BITS = 6.0
+
+// Return the terminal escape sequence to show a guess that cut the words
+// from numBefore to numAfter: a shade of gray from dim, for a guess that
+// told us nothing, to white.
+func tintFor(numBefore int, numAfter int) string {
+	bits := 0.0
+	if numAfter > 0 && numBefore > numAfter {
+		bits = math.Log2(float64(numBefore) / float64(numAfter))
+	}
+	// 244 to 255 are the lighter half of the 256-color grays.
+	shade := 244 + int(math.Round(math.Min(bits/MAX_TINT_BITS, 1)*11))
+	return fmt.Sprintf("\033[38;5;%vm", shade)
+}
+
+const TINT_RESET = "\033[0m"
+
+// Return the number of columns text takes on the screen.  Emoji, such as
+// the colored squares, take two.
+func displayWidth(text string) int {
+	width := 0
+	for _, ch := range text {
+		width += runeWidth(ch)
+	}
+	return width
+}
+
+func runeWidth(ch rune) int {
+	if ch >= 0x1f000 || (ch >= 0x2b00 && ch <= 0x2bff) {
+		return 2
+	}
+	return 1
+}
+
+// Print text, breaking it into lines of at most width columns.  If tint
+// is not "", it is the escape sequence to show each line in.
+func printWrapped(text string, width int, tint string) {
+	printLine := func(line string) {
+		if len(tint) > 0 {
+			fmt.Println(tint + line + TINT_RESET)
+		} else {
+			fmt.Println(line)
+		}
+	}
+	line := ""
+	lineWidth := 0
+	for _, ch := range text {
+		if lineWidth+runeWidth(ch) > width && lineWidth > 0 {
+			printLine(line)
+			line = ""
+			lineWidth = 0
+		}
+		line += string(ch)
+		lineWidth += runeWidth(ch)
+	}
+	printLine(line)
+}
+
+// Report whether word has already been guessed.
+func (solver *Solver) alreadyGuessed(word string) bool {
+	for _, entry := range solver.history {
+		if entry.guess == word {
+			return true
+		}
+	}
+	return false
+}
+
+// Return the candidates that have not already been guessed.  Guessing one
+// again could only give the same response, so the solver would suggest it
+// forever.  If every candidate has been guessed, a response must have been
+// entered wrongly; say so.
+func (solver *Solver) untriedCandidates() []string {
+	var untried []string
+	candidates := solver.findCandidates()
+	for _, word := range candidates {
+		if !solver.alreadyGuessed(word) {
+			untried = append(untried, word)
+		}
+	}
+	if len(untried) == 0 && len(candidates) > 0 {
+		warnAlreadyGuessed(candidates[0])
+	}
+	return untried
+}
+
+// Warn that the only words left that fit the clues, such as word, have
+// already been guessed.
+func warnAlreadyGuessed(word string) {
+	fmt.Println("The only words that fit the clues, such as " + word +
+		", have already been guessed; one of the responses must be wrong")
+}
+
+// Group candidates by the response we would get if we guessed guess and
+// the candidate were the answer.  The result maps each possible response
+// to the number of candidates that would produce it.
+func responseBuckets(guess string, candidates []string) map[string]int {
+	buckets := make(map[string]int)
+	for _, candidate := range candidates {
+		buckets[evaluateGuess(guess, candidate)]++
+	}
+	return buckets
+}
+
+// Return the expected number of candidates left after a guess whose
+// responses split numCandidates candidates into buckets.
+func expectedRemaining(buckets map[string]int, numCandidates int) float64 {
+	sumOfSquares := 0
+	for _, count := range buckets {
+		sumOfSquares += count * count
+	}
+	return float64(sumOfSquares) / float64(numCandidates)
+}
+
+// Report how many candidates would remain after guessing word, without
+// applying anything to the clues.  The average is weighted by how likely
+// each response is, i.e. it's the expected number of remaining candidates.
+func (solver *Solver) tryWord(word string) {
+	if utf8.RuneCountInString(word) != LETTERS_IN_WORD {
+		fmt.Printf("Words must be of length %v\n", LETTERS_IN_WORD)
+		return
+	}
+	candidates := solver.findCandidates()
+	if len(candidates) == 0 {
+		fmt.Println("There are no candidates left")
+		return
+	}
+	buckets := responseBuckets(word, candidates)
+	worst := 0
+	best := len(candidates)
+	for _, count := range buckets {
+		if count > worst {
+			worst = count
+		}
+		if count < best {
+			best = count
+		}
+	}
+	average := expectedRemaining(buckets, len(candidates))
+	fmt.Printf("%v: %v candidates, %v possible responses; remaining worst %v, best %v, average %.1f\n",
+		word, len(candidates), len(buckets), worst, best, average)
+}
+
+// The most letters the freqs command lists.
+const MAX_FREQS_SHOWN = 8
+
+// Print the letters not yet known to be in the word that are in the most
+// candidates, with how many of them each is in, to help choose a guess
+// that tests the letters most worth testing.
+func (solver *Solver) printLetterFrequencies() {
+	candidates := solver.findCandidates()
+	if len(candidates) == 0 {
+		fmt.Println("There are no candidates left")
+		return
+	}
+	counts := make(map[string]int)
+	for _, word := range candidates {
+		for letter := range makeMapFromWord(word) {
+			if solver.requiredLetters[letter] == 0 {
+				counts[letter]++
+			}
+		}
+	}
+	var letters []string
+	for letter := range counts {
+		letters = append(letters, letter)
+	}
+	sort.Slice(letters, func(i, j int) bool {
+		if counts[letters[i]] == counts[letters[j]] {
+			return letters[i] < letters[j]
+		}
+		return counts[letters[i]] > counts[letters[j]]
+	})
+	if len(letters) > MAX_FREQS_SHOWN {
+		letters = letters[:MAX_FREQS_SHOWN]
+	}
+	var shown []string
+	for _, letter := range letters {
+		shown = append(shown, fmt.Sprintf("%v %v", letter, counts[letter]))
+	}
+	if len(shown) == 0 {
+		fmt.Println("Every letter in the candidates is already known to be in the word")
+		return
+	}
+	fmt.Printf("Letters not yet known, by how many of the %v candidates have them: %v\n",
+		len(candidates), strings.Join(shown, ", "))
+}
+
+// The most words from one board we will score when choosing a guess for
+// several boards at once.  This keeps the early guesses fast.
+const MAX_BOARD_GUESS_POOL = 200
+
+// Choose the next guess when solving several boards at once.  We only
+// consider words that could be the answer on the unsolved board that is
+// closest to being solved, and from those pick the word that is expected
+// to leave the fewest candidates in total across all unsolved boards.
+func chooseGuessForBoards(boards []*Solver) string {
+	var unsolved []*Solver
+	var boardCandidates [][]string
+	var pool []string
+	for _, solver := range boards {
+		if solver.solved {
+			continue
+		}
+		candidates := solver.untriedCandidates()
+		unsolved = append(unsolved, solver)
+		boardCandidates = append(boardCandidates, candidates)
+		if len(candidates) > 0 && (len(pool) == 0 || len(candidates) < len(pool)) {
+			pool = candidates
+		}
+	}
+	if len(pool) <= 1 || len(unsolved) == 1 {
+		if len(pool) == 0 {
+			return ""
+		}
+		return pool[0]
+	}
+	if len(pool) > MAX_BOARD_GUESS_POOL {
+		pool = pool[:MAX_BOARD_GUESS_POOL]
+	}
+	bestGuess := ""
+	bestScore := 0
+	for _, guess := range pool {
+		// Sum of the squares of the bucket sizes is proportional to the
+		// expected number of candidates left on a board.
+		score := 0
+		for _, candidates := range boardCandidates {
+			for _, count := range responseBuckets(guess, candidates) {
+				score += count * count
+			}
+		}
+		if len(bestGuess) == 0 || score < bestScore {
+			bestGuess = guess
+			bestScore = score
+		}
+	}
+	return bestGuess
+}
+
+func printBoardStatus(boards []*Solver, showCount bool) {
+	for i, solver := range boards {
+		if solver.solved {
+			fmt.Printf("Board %v: solved (%v)\n", i+1, solver.answer)
+		} else if showCount {
+			fmt.Printf("Board %v: %v\n", i+1, formatCandidateCount(len(solver.findCandidates())))
+		} else {
+			fmt.Printf("Board %v: %v candidates\n", i+1, len(solver.findCandidates()))
+		}
+	}
+}
+
+// Return numCandidates as a fraction of the word list, such as
+// "12 / 2829 candidates (0.4%)".
+func formatCandidateCount(numCandidates int) string {
+	return fmt.Sprintf("%v / %v candidates (%.1f%%)", numCandidates, len(AllWords),
+		100*float64(numCandidates)/float64(len(AllWords)))
+}
+
+// What typing help or ? at the Resp: prompt shows.
+var GUESS_HELP = []string{
+	"Type the response to my guess, with a letter for each letter of it:",
+	"  y  the letter is in the word, in that spot",
+	"  p  the letter is in the word, but in another spot",
+	"  n  the letter is not in the word (or not as many times as I guessed it)",
+	"For example, nnpny. Instead of a response you can type:",
+	"  try word    show how many words would be left after guessing word",
+	"  check word  show whether word fits the responses so far, and if not, why not",
+	"  no letter   rule out a letter you know isn't in the word",
+	"  yes letter [count]",
+	"              require a letter you know is in the word, at least count times",
+	"  regex       show the responses so far as a regular expression",
+	"  entropy     show how much information is still needed to find the word",
+	"  freqs       show the letters not yet known that are in the most words left",
+	"  save file   save the game to file, to carry on later with --resume",
+	"  help or ?   show this message",
+	"  q           quit",
+}
+
+func printGuessHelp() {
+	for _, line := range GUESS_HELP {
+		fmt.Println(line)
+	}
+}
+
+// If line is a solver command rather than a response, carry it out and
+// return true.  The commands are:
+//
+//	try word    report how many candidates would remain after guessing word
+//	check word  report whether word fits the clues, and if not, why not
+//	no letter   rule letter out of the word
+//	yes letter [count]
+//	            require at least count copies of letter (default 1)
+//	regex       show the clues as a regular expression
+//	entropy     show how many bits of information are still missing
+//	freqs       show the untested letters in the most candidates
+//	help, ?     list these commands
+//
+// doGuesses also handles "save path", which needs all the boards.
+func (solver *Solver) handleCommand(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 1 && fields[0] == "regex" {
+		solver.printRegex()
+		return true
+	}
+	if len(fields) == 1 && (fields[0] == "help" || fields[0] == "?") {
+		printGuessHelp()
+		return true
+	}
+	if len(fields) == 1 && fields[0] == "entropy" {
+		printEntropy(solver.untriedCandidates(), solver.knowsNothing())
+		return true
+	}
+	if len(fields) == 1 && fields[0] == "freqs" {
+		solver.printLetterFrequencies()
+		return true
+	}
+	if len(fields) == 3 && fields[0] == "yes" {
+		count, err := strconv.Atoi(fields[2])
+		if err != nil {
+			fmt.Println("The count must be a number")
+		} else {
+			solver.requireLetter(fields[1], count)
+		}
+		return true
+	}
+	if len(fields) != 2 {
+		return false
+	}
+	switch fields[0] {
+	case "try":
+		solver.tryWord(fields[1])
+	case "check":
+		solver.checkWord(fields[1])
+	case "no":
+		solver.excludeLetter(fields[1])
+	case "yes":
+		solver.requireLetter(fields[1], 1)
+	default:
+		return false
+	}
+	return true
+}
+
+// Guess numBoards words at once, Quordle-style: each guess is applied
+// to every board that is not yet solved, and the user enters a response
+// for each of those boards.
+// Play guess mode.  Return whether every board was solved, or an error
+// if the session to resume could not be loaded.
+func doGuesses(settings Settings) (bool, error) {
+	numBoards := settings.boards
 	fmt.Println(("doGuesses here"))
-	var validLetters [LETTERS_IN_WORD]StringSet
-	for i, _ := range validLetters {
-		validLetters[i] = make(map[string]bool)
+	boards := make([]*Solver, numBoards)
+	for i := range boards {
+		boards[i] = NewSolver()
 	}
-	alphabet := "abcdefghijklmnopqrstuvwxyz"
-	for idx := 0; idx < len(validLetters); idx++ {
-		for ia := 0; ia < len(alphabet); ia++ {
-			validLetters[idx].Add(alphabet[ia : ia+1])
+	if len(settings.resumeFile) > 0 {
+		var err error
+		boards, err = loadSession(settings.resumeFile)
+		if err != nil {
+			return false, err
 		}
+		numBoards = len(boards)
 	}
 
 	var response string = ""
-	for {
-		//printSetOfValidLetters(&validLetters)
+	allSolved := false
+	for quit := false; !quit; {
+		//boards[0].printSetOfValidLetters()
 		var myGuess string
-		// Loop through the list of words, finding the first one
-		// that matches the clues we have so far.
-		for _, guess := range AllWords {
-			matches := true
-			// Loop through the letters of this guess.
-			for ilet := 0; ilet < len(guess); ilet++ {
-				if !validLetters[ilet].Contains(guess[ilet : ilet+1]) {
-					// This potential guess is incompatible with the clues so far,
-					// so stop analyzing this potential guess.
-					matches = false
-					break
-				}
+		if numBoards == 1 {
+			if settings.showScores {
+				showScores(boards[0].findCandidates(), boards[0].knowsNothing())
 			}
-			if matches {
-				// The word matches according to validLetters, but does it have
-				// all the letters we know are in the word?
-				mapLetterToCountThisWord := makeMapFromWord(guess)
-				for letter, numRequired := range requiredLetters {
-					countThisGuess, present := mapLetterToCountThisWord[letter]
-					if !present {
-						matches = false
-					} else if countThisGuess < numRequired {
-						matches = false
+			var scanned bool
+			myGuess, scanned = boards[0].chooseGuessScanned()
+			if settings.explain && len(myGuess) > 0 && len(boards[0].history) > 0 {
+				explainGuess(myGuess, boards[0].untriedCandidates())
+			}
+			if settings.verbose && scanned {
+				boards[0].printScan(myGuess)
+			}
+		} else {
+			myGuess = chooseGuessForBoards(boards)
+		}
+		if len(myGuess) == 0 {
+			// There is nothing to ask for a response to.
+			fmt.Println("I could not find a matching word, so I give up")
+			allSolved = false
+			break
+		}
+		fmt.Println(myGuess)
+
+		allSolved = true
+		for i, solver := range boards {
+			if solver.solved {
+				continue
+			}
+			prompt := "Resp: "
+			if numBoards > 1 {
+				prompt = fmt.Sprintf("Resp %v: ", i+1)
+			}
+			for {
+				// Read the response, handling any commands the user enters instead.
+				for {
+					fmt.Print(prompt)
+					var ok bool
+					response, ok = readLine()
+					if !ok {
+						// At the end of input, stop as if the user had quit.
+						fmt.Println()
+						response = "q"
+						break
+					}
+					fields := strings.Fields(response)
+					if len(fields) == 2 && fields[0] == "save" {
+						if err := saveSession(fields[1], boards); err != nil {
+							fmt.Println("Cannot save: " + err.Error())
+						} else {
+							fmt.Println("Saved to " + fields[1])
+						}
+					} else if !solver.handleCommand(response) {
+						break
 					}
 				}
-				if matches {
-					myGuess = guess
-					fmt.Println(myGuess)
+				if response == "q" {
+					break
+				}
+				// Keep a copy of the clues, in case the response was a mistake.
+				before := solver.save()
+				numBefore := len(solver.findCandidates())
+				found, err := solver.processResponse(myGuess, response)
+				if err != nil {
+					// Ask this board for the response again.
+					fmt.Println(err)
+					continue
+				}
+				if found {
 					break
 				}
+				numAfter := len(solver.findCandidates())
+				if (numAfter == 0 || numAfter == numBefore) && !confirmResponse(numAfter) {
+					restored, _ := restoreSolver(before)
+					*solver = *restored
+					continue
+				}
+				if settings.explain && numBoards == 1 {
+					explainResponse(myGuess, response, numBefore, numAfter)
+				}
+				if settings.regex {
+					solver.printRegex()
+				}
+				if settings.verbose && numAfter > 0 {
+					printEntropy(solver.untriedCandidates(), false)
+				}
+				if settings.showCount && numBoards == 1 {
+					fmt.Println(formatCandidateCount(numAfter))
+				}
+				if settings.estimate && numAfter > 0 {
+					candidates := solver.untriedCandidates()
+					fmt.Printf("~%.1f guesses remaining (%v candidates)\n",
+						estimateGuessesLeft(candidates), len(candidates))
+				}
+				break
+			}
+			if response == "q" {
+				quit = true
+				allSolved = false
+				break
+			}
+			if !solver.solved {
+				allSolved = false
 			}
 		}
-		if len(myGuess) == 0 {
-			fmt.Println("I could not find a matching word")
+		if settings.showBoard && !quit {
+			width := settings.width
+			if width == 0 {
+				width = terminalWidth()
+			}
+			for i, solver := range boards {
+				if numBoards > 1 {
+					fmt.Printf("Board %v:\n", i+1)
+				}
+				solver.printHistory(width, settings.tint)
+			}
 		}
-
-		fmt.Print("Resp: ")
-		response = readGuessResult()
-		if response == "q" {
-			break
+		if numBoards > 1 && !quit {
+			printBoardStatus(boards, settings.showCount)
 		}
-		if processResponse(&validLetters, myGuess, response) {
-			break
+		if allSolved {
+			quit = true
 		}
 	}
+	return allSolved, nil
+}
+
+// On Ctrl-C, put the terminal back, and tell the player the word (if a
+// game is in progress) before exiting with EXIT_INTERRUPTED, rather than
+// leaving them wondering.
+func handleInterrupts() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		<-signals
+		fmt.Println()
+		restoreEcho()
+		if word, _ := currentSecret.Load().(string); len(word) > 0 {
+			fmt.Println("The word was " + word)
+		}
+		os.Exit(EXIT_INTERRUPTED)
+	}()
 }
 
 func main() {
-	settings := parseCmdLine()
+	settings := parseCmdLine(os.Args[1:])
 	if len(settings.errMsg) != 0 {
 		fmt.Println(settings.errMsg)
 		usage()
-	} else {
-		MyScanner = *bufio.NewScanner(os.Stdin)
-		if settings.runType == GUESS {
-			doGuesses()
-		} else if settings.runType == RUN {
-			runGame(settings.word)
+		os.Exit(EXIT_USAGE)
+	}
+	os.Exit(run(settings))
+}
+
+// Do whatever settings say, and return the exit code.  This is separate
+// from main so that deferred calls run before the program exits.
+func run(settings Settings) int {
+	MyScanner = *bufio.NewScanner(os.Stdin)
+	handleInterrupts()
+	if settings.seed != 0 {
+		rng.Seed(settings.seed)
+	}
+	if err := selectWordList(settings.list, settings.verbose); err != nil {
+		fmt.Println(err)
+		return EXIT_IO_ERROR
+	}
+	alphabet = settings.alphabet
+	if err := checkAlphabet(settings.list); err != nil {
+		fmt.Println(err)
+		return EXIT_USAGE
+	}
+	if settings.verbose {
+		printWordListStats(settings.list)
+	}
+	if len(settings.category) > 0 {
+		if err := selectCategory(settings.category); err != nil {
+			fmt.Println(err)
+			return EXIT_USAGE
+		}
+	}
+	answers := answerPool(settings.noPlurals, settings.locked)
+	if len(answers) == 0 && len(settings.locked) > 0 {
+		fmt.Println("No word in the word list fits --locked=" + settings.locked)
+		return EXIT_USAGE
+	} else if len(answers) == 0 {
+		fmt.Println("The word list has no words that are not plurals or proper nouns")
+		return EXIT_USAGE
+	}
+	if settings.categoryWeights != nil {
+		if err := checkCategoryWeights(answers, settings.categoryWeights); err != nil {
+			fmt.Println(err)
+			return EXIT_USAGE
+		}
+	}
+	if settings.runType == RUN && len(settings.seedText) > 0 && len(settings.word) == 0 {
+		idx := int(hashSeed(settings.seedText) % uint64(len(answers)))
+		if settings.verbose {
+			fmt.Printf("Seed %v gives word number %v of %v\n", settings.seedText, idx, len(answers))
+		}
+		settings.word = answers[idx]
+	}
+	applyWordOrder(settings.order)
+	activeStrategy = settings.strategy
+	cacheDir = settings.cacheDir
+	activeTiebreak = settings.tiebreak
+	randomTies = settings.randomTies
+	lookaheadWidth = settings.lookaheadWidth
+	probeUnknowns = settings.probeUnknowns
+	vowelsFirst = settings.vowelsFirst
+	confirmAnswer = settings.confirm
+	injectedResponses = settings.injections
+	fixedGuesses = settings.firstGuesses
+	if len(fixedGuesses) == 0 && settings.runType != ANALYZE && !vowelsFirst {
+		if opener := cachedOpener(); len(opener) > 0 {
+			fixedGuesses = []string{opener}
+		}
+	}
+	if len(settings.openingsFile) > 0 {
+		if err := loadOpenings(settings.openingsFile); err != nil {
+			fmt.Println("Cannot load openings: " + err.Error())
+			return EXIT_IO_ERROR
+		}
+	}
+	if len(settings.cpuProfile) > 0 {
+		stopCPUProfile, err := startCPUProfile(settings.cpuProfile)
+		if err != nil {
+			fmt.Println("Cannot write CPU profile: " + err.Error())
+			return EXIT_IO_ERROR
+		}
+		defer stopCPUProfile()
+	}
+	if len(settings.memProfile) > 0 {
+		defer writeMemProfile(settings.memProfile)
+	}
+	solved := true
+	if settings.runType == GUESS && len(settings.target) > 0 {
+		solved = autoSolve(settings.target, settings.finalOnly)
+	} else if settings.runType == GUESS {
+		var err error
+		solved, err = doGuesses(settings)
+		if err != nil {
+			fmt.Println("Cannot resume: " + err.Error())
+			return EXIT_IO_ERROR
+		}
+	} else if settings.runType == RUN {
+		if len(settings.scenarioFile) > 0 {
+			if err := loadScenario(settings.scenarioFile, &settings); err != nil {
+				fmt.Println("Cannot load scenario: " + err.Error())
+				return EXIT_IO_ERROR
+			}
+		}
+		var tally SessionTally
+		writeFailed := false
+		for {
+			if settings.askSecret {
+				word, ok := readSecret(settings.strictSecret)
+				if !ok {
+					return EXIT_LOST
+				}
+				settings.word = word
+			}
+			if len(settings.word) > 0 {
+				for _, word := range append([]string{settings.word}, settings.alsoAccepted...) {
+					if err := checkSecretWord(word); err != nil {
+						fmt.Println(err)
+						return EXIT_USAGE
+					}
+				}
+			}
+			if len(settings.word) > 0 && !fitsLocked(settings.word, settings.locked) {
+				fmt.Printf("The word %v doesn't fit --locked=%v\n", settings.word, settings.locked)
+				return EXIT_USAGE
+			}
+			if len(settings.word) > 0 && !isKnownWord(settings.word) {
+				// For --ask-secret, readSecret has already dealt with it,
+				// without showing the word.
+				if settings.strictSecret && !settings.askSecret {
+					fmt.Printf("The word %v is not in the word list, as --strict-secret requires\n", settings.word)
+					return EXIT_USAGE
+				}
+				if !settings.askSecret {
+					fmt.Printf("Warning: the word %v is not in the word list; playing it anyway\n", settings.word)
+				}
+				if settings.strict {
+					fmt.Println("Only guesses in the word list are accepted, so it can't be guessed without --strict=false")
+				}
+			}
+			var numGuesses int
+			var err error
+			solved, numGuesses, err = runGame(settings)
+			if err != nil {
+				fmt.Println(err)
+				writeFailed = true
+			}
+			if !settings.again {
+				break
+			}
+			tally.add(solved, numGuesses)
+			tally.print()
+			if !playAgain() {
+				break
+			}
+			// A word from a text --seed is for the first game only.
+			settings.word = ""
+		}
+		if writeFailed {
+			return EXIT_IO_ERROR
+		}
+	} else if settings.runType == REPLAY {
+		if err := replayTranscript(settings.replayFile, settings.word); err != nil {
+			fmt.Println("Cannot read transcript: " + err.Error())
+			return EXIT_IO_ERROR
+		}
+	} else if settings.runType == HARDEST {
+		showHardest(settings.numHardest)
+	} else if settings.runType == SELFCHECK {
+		if !selfCheck(settings.numSelfCheck) {
+			return EXIT_TEST_FAILED
+		}
+	} else if settings.runType == MAKE_OPENINGS {
+		if err := makeOpenings(settings.makeOpeningsFile); err != nil {
+			fmt.Println("Cannot write openings: " + err.Error())
+			return EXIT_IO_ERROR
+		}
+	} else if settings.runType == BENCHMARK {
+		if err := benchmark(settings.freqFile, settings.minFreq, settings.sampleSize); err != nil {
+			fmt.Println("Cannot load frequencies: " + err.Error())
+			return EXIT_IO_ERROR
 		}
+	} else if settings.runType == COMPARE {
+		if err := compareStrategies(settings.freqFile, settings.minFreq, settings.sampleSize); err != nil {
+			fmt.Println("Cannot load frequencies: " + err.Error())
+			return EXIT_IO_ERROR
+		}
+	} else if settings.runType == FIND {
+		if err := findWords(settings.pattern, settings.contains, settings.exclude); err != nil {
+			fmt.Println(err)
+			return EXIT_USAGE
+		}
+	} else if settings.runType == TEST {
+		if !runSelfTests() {
+			return EXIT_TEST_FAILED
+		}
+	} else if settings.runType == PROVE_SOLVABLE {
+		maxGuesses := settings.maxGuesses
+		if maxGuesses == 0 {
+			maxGuesses = MAX_GUESSES
+		}
+		if !proveSolvable(maxGuesses) {
+			return EXIT_TEST_FAILED
+		}
+	} else if settings.runType == MAKE_SHARE {
+		if err := makeShareBlock(settings); err != nil {
+			fmt.Println(err)
+			return EXIT_USAGE
+		}
+	} else if settings.runType == DIFF_LISTS {
+		if err := diffLists(settings.diffLists[0], settings.diffLists[1], settings.verbose); err != nil {
+			fmt.Println("Cannot read word list " + err.Error())
+			return EXIT_IO_ERROR
+		}
+	} else if settings.runType == EXPORT_TREE {
+		maxGuesses := settings.maxGuesses
+		if maxGuesses == 0 {
+			maxGuesses = MAX_GUESSES
+		}
+		if err := exportDecisionTree(settings.treeFile, maxGuesses); err != nil {
+			fmt.Println("Cannot write " + settings.treeFile + ": " + err.Error())
+			return EXIT_IO_ERROR
+		}
+	} else if settings.runType == SUGGEST {
+		if err := suggestFrom(settings.suggestFile, settings.showScores); err != nil {
+			fmt.Println("Cannot read candidates: " + err.Error())
+			return EXIT_IO_ERROR
+		}
+	} else if settings.runType == RATE {
+		if err := rateWord(settings.rateWord); err != nil {
+			fmt.Println(err)
+			return EXIT_USAGE
+		}
+	} else if settings.runType == DISTINGUISH {
+		distinguish(settings.distinguish[0], settings.distinguish[1])
+	} else if settings.runType == UNIQUENESS {
+		if err := reportUniqueness(settings.uniquenessWord); err != nil {
+			fmt.Println(err)
+			return EXIT_USAGE
+		}
+	} else if settings.runType == FROM_SHARE {
+		if err := fromShare(settings.shareFile, settings.guesses); err != nil {
+			fmt.Println("Cannot read shared result: " + err.Error())
+			return EXIT_IO_ERROR
+		}
+	} else if settings.runType == ANALYZE {
+		if err := analyzeOpener(); err != nil {
+			fmt.Println("Cannot cache the first guess: " + err.Error())
+			return EXIT_IO_ERROR
+		}
+	}
+	if !solved {
+		return EXIT_LOST
 	}
+	return EXIT_OK
 }
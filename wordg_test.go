@@ -0,0 +1,537 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// Run play as if the user typed input, with words as the word list and
+// the first strategy with no fixed guesses, and return what it printed.
+func playWith(t *testing.T, input string, words []string, play func()) string {
+	t.Helper()
+	out, err := os.CreateTemp(t.TempDir(), "stdout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	savedScanner, savedStdout, savedWords := MyScanner, os.Stdout, AllWords
+	savedGuesses, savedStrategy := fixedGuesses, activeStrategy
+	MyScanner = *bufio.NewScanner(strings.NewReader(input))
+	os.Stdout = out
+	AllWords = words
+	fixedGuesses, activeStrategy = nil, "first"
+	defer func() {
+		MyScanner, os.Stdout, AllWords = savedScanner, savedStdout, savedWords
+		fixedGuesses, activeStrategy = savedGuesses, savedStrategy
+	}()
+	play()
+	output, err := os.ReadFile(out.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(output)
+}
+
+func TestEvaluateGuessByLetter(t *testing.T) {
+	cases := []struct {
+		guess    string
+		word     string
+		response string
+	}{
+		{"crane", "crane", "yyyyy"},
+		{"señor", "señor", "yyyyy"},
+		{"soñar", "señor", "ypyny"},
+		{"canon", "cañón", "yynny"},
+	}
+	for _, c := range cases {
+		if got := evaluateGuess(c.guess, c.word); got != c.response {
+			t.Errorf("evaluateGuess(%q, %q) = %v, want %v", c.guess, c.word, got, c.response)
+		}
+	}
+}
+
+func TestGuessLengthInLetters(t *testing.T) {
+	cases := []struct {
+		guess string
+		want  string
+	}{
+		// Six bytes, five letters.
+		{"señor", "Congratulations!"},
+		{"señores", "señores is too long"},
+		{"año", "año is too short"},
+		// Five bytes, four letters.
+		{"añoo", "añoo is too short"},
+	}
+	savedAlphabet := alphabet
+	alphabet = DEFAULT_ALPHABET + "ñ"
+	defer func() { alphabet = savedAlphabet }()
+	for _, c := range cases {
+		output := playWith(t, c.guess+"\nq\n", []string{"crane", "señor"}, func() {
+			runGame(Settings{word: "señor"})
+		})
+		if !strings.Contains(output, c.want) {
+			t.Errorf("guessing %v printed %q, want it to include %q", c.guess, output, c.want)
+		}
+	}
+}
+
+func TestResponseLength(t *testing.T) {
+	cases := []struct {
+		response string
+		want     string
+	}{
+		{"yyppnnn", "Response is too long: expected 5 characters but got 7 (2 extra)"},
+		{"yyppn yyppn", "Response is too long: expected 5 characters but got 11 (6 extra)"},
+		{" yyp ", "Response is too short: expected 5 characters but got 3"},
+	}
+	for _, c := range cases {
+		output := playWith(t, c.response+"\nq\n", []string{"crane", "slate", "stale"}, func() {
+			doGuesses(Settings{boards: 1})
+		})
+		if !strings.Contains(output, c.want) {
+			t.Errorf("the response %q printed %q, want it to include %q", c.response, output, c.want)
+		}
+	}
+	// White space around a response is not part of it.
+	output := playWith(t, "  nnyny\t\nq\n", []string{"crane", "slate", "stale"}, func() {
+		doGuesses(Settings{boards: 1})
+	})
+	if strings.Contains(output, "Response is") {
+		t.Errorf("the padded response nnyny was rejected: %q", output)
+	}
+}
+
+func TestRepeatedLetters(t *testing.T) {
+	scoring := []struct {
+		guess    string
+		word     string
+		response string
+	}{
+		// A letter guessed twice, but in the word once.
+		{"speed", "abide", "nnpnp"},
+		{"erase", "there", "ppnny"},
+		// A letter guessed twice, and in the word twice.
+		{"llama", "hello", "ppnnn"},
+		{"lolly", "hello", "npyyn"},
+		// One copy in place and one elsewhere.
+		{"erase", "theme", "pnnny"},
+		{"eerie", "where", "pnpny"},
+	}
+	for _, c := range scoring {
+		if got := evaluateGuess(c.guess, c.word); got != c.response {
+			t.Errorf("evaluateGuess(%q, %q) = %v, want %v", c.guess, c.word, got, c.response)
+		}
+	}
+	// After guess, target must still fit the clues, and each word in
+	// ruledOut must not.
+	solving := []struct {
+		target   string
+		guess    string
+		ruledOut []string
+	}{
+		{"there", "erase", []string{"erase", "geese"}},
+		{"theme", "erase", []string{"chime", "elude", "elope", "thyme"}},
+		{"where", "eerie", []string{"merge", "three", "sheer"}},
+	}
+	for _, c := range solving {
+		solver := NewSolver()
+		response := evaluateGuess(c.guess, c.target)
+		solver.processResponse(c.guess, response)
+		if !solver.matchesClues(c.target) {
+			t.Errorf("after %v %v, %v no longer fits", c.guess, response, c.target)
+		}
+		for _, word := range c.ruledOut {
+			if solver.matchesClues(word) {
+				t.Errorf("after %v %v, %v still fits", c.guess, response, word)
+			}
+		}
+	}
+}
+
+func TestCRLFInput(t *testing.T) {
+	words := []string{"crane", "slate", "stale"}
+	output := playWith(t, "crane\r\n", words, func() {
+		runGame(Settings{word: "crane"})
+	})
+	if !strings.Contains(output, "Congratulations!") {
+		t.Errorf("run mode with CRLF input printed %q", output)
+	}
+	// Guess mode stops at the end of input, so no q is needed.
+	var solved bool
+	playWith(t, "yyyyy\r\n", words, func() {
+		solved, _ = doGuesses(Settings{boards: 1})
+	})
+	if !solved {
+		t.Error("guess mode with CRLF input did not accept yyyyy")
+	}
+}
+
+// A word list whose letters are emoji, to check that nothing assumes a
+// letter is a byte.
+var emojiWords = []string{"🍎🍌🍒🍇🍉", "🍒🍎🍌🍉🍇", "🍋🍋🍎🍐🍑"}
+
+func TestEmojiWords(t *testing.T) {
+	savedAlphabet := alphabet
+	alphabet = "🍎🍌🍒🍇🍉🍋🍐🍑"
+	defer func() { alphabet = savedAlphabet }()
+	secret := emojiWords[1]
+	output := playWith(t, emojiWords[2]+"\n"+secret+"\n", emojiWords, func() {
+		runGame(Settings{word: secret})
+	})
+	if !strings.Contains(output, "Congratulations!") {
+		t.Errorf("run mode with emoji words printed %q", output)
+	}
+	var solved bool
+	playWith(t, evaluateGuess(emojiWords[0], secret)+"\nyyyyy\n", emojiWords, func() {
+		solved, _ = doGuesses(Settings{boards: 1})
+	})
+	if !solved {
+		t.Errorf("guess mode with emoji words did not find %v", secret)
+	}
+	solver := NewSolver()
+	response := evaluateGuess(emojiWords[0], secret)
+	solver.processResponse(emojiWords[0], response)
+	if !solver.matchesClues(secret) {
+		t.Errorf("after %v %v, %v no longer fits", emojiWords[0], response, secret)
+	}
+	var candidates []string
+	playWith(t, "", emojiWords, func() { candidates = solver.findCandidates() })
+	if len(candidates) != 1 {
+		t.Errorf("after %v %v, the candidates are %v, want only %v", emojiWords[0], response, candidates, secret)
+	}
+}
+
+func TestEmojiResponses(t *testing.T) {
+	cases := []struct {
+		response string
+		want     string
+	}{
+		// Characters of more than one byte count once.
+		{"yy🍎pn", "Unexpected response char: 🍎"},
+		{"🍎🍎🍎", "Response is too short: expected 5 characters but got 3"},
+	}
+	for _, c := range cases {
+		if err := checkResponse(c.response); err == nil || err.Error() != c.want {
+			t.Errorf("checkResponse(%q) = %v, want %v", c.response, err, c.want)
+		}
+	}
+}
+
+func TestCountsIncludeManualClues(t *testing.T) {
+	// A letter ruled out with the no command counts toward the words left
+	// after the guess it followed.
+	var counts []int
+	playWith(t, "", []string{"crane", "slate", "stale", "shale"}, func() {
+		solver := NewSolver()
+		solver.processResponse("crane", evaluateGuess("crane", "shale"))
+		solver.excludeLetter("t")
+		counts = solver.candidateCounts()
+	})
+	if len(counts) != 1 || counts[0] != 1 {
+		t.Errorf("after crane and no t, the words left were counted as %v, want [1]", counts)
+	}
+}
+
+func TestUselessGuessWithNoCandidates(t *testing.T) {
+	// When no word in the list fits, as when the word isn't in it, the
+	// coach can't tell whether a guess was useless.
+	solver := NewSolver()
+	solver.processResponse("crane", "nnnnn")
+	if isUselessGuess(solver, "crane", nil) {
+		t.Error("with no words left, crane was judged useless")
+	}
+}
+
+func TestScoring(t *testing.T) {
+	cases := []struct {
+		guess    string
+		word     string
+		response string
+	}{
+		{"slate", "crane", "nnyny"},
+		{"zzzzz", "crane", "nnnnn"},
+		{"nacre", "crane", "ppppy"},
+		{"state", "valet", "nppnp"},
+		{"eerie", "there", "pnpny"},
+		{"erase", "geese", "pnnyy"},
+		{"sassy", "essay", "ppyny"},
+		{"esses", "essay", "yyynn"},
+		// The --wildcards letter, which is never in the word.
+		{"cr*ne", "crane", "yynyy"},
+		// An accented letter guessed three times, but in the word once.
+		{"ñañña", "cañón", "nyynn"},
+		// An accented letter guessed three times, and in the word twice.
+		{"éaéaé", "ééaab", "yppyn"},
+	}
+	for _, c := range cases {
+		if got := evaluateGuess(c.guess, c.word); got != c.response {
+			t.Errorf("evaluateGuess(%q, %q) = %v, want %v", c.guess, c.word, got, c.response)
+		}
+	}
+}
+
+func TestCheckGuessLength(t *testing.T) {
+	cases := []struct {
+		guess string
+		err   string
+	}{
+		{"crane", ""},
+		{"cranes", "too long"},
+		{"cran", "too short"},
+		{"cañón", ""},
+		{"señores", "too long"},
+		// Five bytes, four letters.
+		{"añoo", "too short"},
+		// Six bytes, three letters.
+		{"ñññ", "too short"},
+	}
+	for _, c := range cases {
+		err := checkGuessLength(c.guess)
+		if c.err == "" && err != nil {
+			t.Errorf("checkGuessLength(%q) = %v, want no error", c.guess, err)
+		} else if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+			t.Errorf("checkGuessLength(%q) = %v, want an error saying it is %v", c.guess, err, c.err)
+		}
+	}
+}
+
+func TestSolverClues(t *testing.T) {
+	// Guesses made against target.  After each response, target must
+	// still fit the clues, and every word in ruledOut must not.
+	cases := []struct {
+		target   string
+		guesses  []string
+		ruledOut []string
+	}{
+		{"crane", []string{"slate"}, []string{"slate", "stale", "shine"}},
+		// An n for the second e, with a p or y for the first: the word
+		// has exactly one e, and the y must survive.
+		{"there", []string{"erase", "eerie"}, []string{"geese", "erase"}},
+		{"abide", []string{"speed"}, []string{"speed", "spend"}},
+		{"hello", []string{"llama", "lolly"}, []string{"llama", "lolly", "holly"}},
+		{"valet", []string{"state"}, []string{"state", "taste"}},
+		// An n for a third s or a second e caps the count: the word has
+		// exactly two s and one e.
+		{"essay", []string{"sassy"}, []string{"sassy", "sissy"}},
+		{"essay", []string{"esses"}, []string{"esses", "esssy", "essae"}},
+		// Letters outside the default alphabet.
+		{"señor", []string{"soñar"}, []string{"sonar", "señal", "soñar"}},
+		{"cañón", []string{"canon"}, []string{"canon", "cañon"}},
+	}
+	savedAlphabet := alphabet
+	alphabet = DEFAULT_ALPHABET + "ñéó"
+	defer func() { alphabet = savedAlphabet }()
+	for _, c := range cases {
+		solver := NewSolver()
+		var steps []string
+		for _, guess := range c.guesses {
+			response := evaluateGuess(guess, c.target)
+			steps = append(steps, guess+" "+response)
+			if _, err := solver.processResponse(guess, response); err != nil {
+				t.Errorf("after %v, the response was rejected: %v", strings.Join(steps, ", "), err)
+			}
+			if !solver.matchesClues(c.target) {
+				t.Errorf("after %v, %v is ruled out: %v", strings.Join(steps, ", "), c.target,
+					solver.explainMismatch(c.target))
+			}
+		}
+		for _, word := range c.ruledOut {
+			if solver.matchesClues(word) {
+				t.Errorf("after %v, %v still fits the clues", strings.Join(steps, ", "), word)
+			}
+		}
+	}
+}
+
+func TestRepeatedWordsCountOnce(t *testing.T) {
+	// Each word must be counted, and be as likely to be chosen, as if it
+	// were listed once, even without dedupWords.
+	words := []string{"crane", "slate", "crane", "stale", "crane"}
+	var numCandidates, numAnswers int
+	var only string
+	playWith(t, "", words, func() {
+		numCandidates = len(NewSolver().findCandidates())
+		numAnswers = len(answerPool(false, ""))
+		solver := NewSolver()
+		solver.processResponse("slate", evaluateGuess("slate", "crane"))
+		only = solver.onlyCandidate()
+	})
+	if numCandidates != 3 || numAnswers != 3 {
+		t.Errorf("with repeated words, %v candidates and %v possible answers, want 3 of each",
+			numCandidates, numAnswers)
+	}
+	if only != "crane" {
+		t.Errorf("with repeated words, the only candidate was %q, want crane", only)
+	}
+}
+
+func TestGuessModeGivesUp(t *testing.T) {
+	// Insisting on a response that no word fits leaves no guess to make,
+	// which must end the game rather than ask for another response.
+	var solved bool
+	output := playWith(t, "yyyyn\ny\nnnnnn\n", []string{"crane", "slate", "stale"}, func() {
+		solved, _ = doGuesses(Settings{boards: 1})
+	})
+	if solved {
+		t.Error("guess mode with no words left claimed to find the word")
+	}
+	if !strings.Contains(output, "I could not find a matching word, so I give up") {
+		t.Errorf("guess mode with no words left printed %q", output)
+	}
+}
+
+func TestCheckSecretWord(t *testing.T) {
+	cases := []struct {
+		word string
+		kind error
+	}{
+		{"crane", nil},
+		{"abc", ErrWrongLength},
+		{"cranes", ErrWrongLength},
+		{"señor", ErrBadLetter},
+	}
+	for _, c := range cases {
+		if err := checkSecretWord(c.word); !errors.Is(err, c.kind) {
+			t.Errorf("checkSecretWord(%q) = %v, want %v", c.word, err, c.kind)
+		}
+	}
+}
+
+func TestWordFlagLowercased(t *testing.T) {
+	t.Setenv(CONFIG_ENV, "")
+	t.Setenv("HOME", t.TempDir())
+	settings := parseCmdLine([]string{"--run", "--word=CRANE", "--word=Slate"})
+	if settings.word != "crane" || len(settings.alsoAccepted) != 1 || settings.alsoAccepted[0] != "slate" {
+		t.Errorf("--word=CRANE --word=Slate gave %q and %v, want crane and [slate]", settings.word, settings.alsoAccepted)
+	}
+}
+
+func TestRunGameWithFixedChooser(t *testing.T) {
+	savedChooser := chooseWord
+	chooseWord = func(words []string) string { return words[len(words)-1] }
+	defer func() { chooseWord = savedChooser }()
+	var solved bool
+	var numGuesses int
+	output := playWith(t, "crane\nslate\nstale\n", []string{"crane", "slate", "stale"}, func() {
+		solved, numGuesses, _ = runGame(Settings{strict: true, maxGuesses: MAX_GUESSES})
+	})
+	if !solved || numGuesses != 3 {
+		t.Errorf("with stale chosen, the game gave solved %v in %v guesses, want true in 3; it printed %q",
+			solved, numGuesses, output)
+	}
+}
+
+func TestRunGameReportsUnwritableLog(t *testing.T) {
+	logFile := t.TempDir() + "/missing/games.log"
+	var err error
+	playWith(t, "crane\n", []string{"crane", "slate"}, func() {
+		_, _, err = runGame(Settings{word: "crane", maxGuesses: MAX_GUESSES, logFile: logFile})
+	})
+	if err == nil {
+		t.Errorf("logging to %v gave no error", logFile)
+	}
+}
+
+func TestCommandsRejectBadWords(t *testing.T) {
+	var errs []error
+	playWith(t, "", []string{"crane", "slate", "stale"}, func() {
+		errs = append(errs, findWords("abc", "", ""), rateWord("zzzzz"), reportUniqueness("zzzzz"))
+	})
+	for i, name := range []string{"--find --pattern=abc", "--rate=zzzzz", "--uniqueness=zzzzz"} {
+		if errs[i] == nil {
+			t.Errorf("%v gave no error", name)
+		}
+	}
+}
+
+func TestSelfCheckReportsProblems(t *testing.T) {
+	// The first strategy tries these one at a time, so the last ones take
+	// more than MAX_GUESSES guesses.
+	words := []string{"bills", "dills", "fills", "gills", "hills", "kills", "mills", "pills", "sills"}
+	var passed bool
+	playWith(t, "", words, func() { passed = selfCheck(len(words)) })
+	if passed {
+		t.Error("selfCheck found no problems in a list the solver can't finish in time")
+	}
+	playWith(t, "", words[:3], func() { passed = selfCheck(3) })
+	if !passed {
+		t.Error("selfCheck found problems in a list of three words")
+	}
+}
+
+func TestConfigValues(t *testing.T) {
+	cases := []struct {
+		json string
+		ok   bool
+	}{
+		{`{"strategy": "entropy"}`, true},
+		{`{"symbols": true}`, true},
+		{`{"max-guesses": 1e6}`, true},
+		{`{"max-guesses": 6.5}`, false},
+		{`{"word": ["crane", "slate"]}`, false},
+		{`{"word": null}`, false},
+	}
+	for _, c := range cases {
+		path := t.TempDir() + "/wordg.json"
+		if err := os.WriteFile(path, []byte(c.json), 0644); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv(CONFIG_ENV, path)
+		flags := flag.NewFlagSet("wordg", flag.ContinueOnError)
+		flags.String("strategy", "", "")
+		flags.Bool("symbols", false, "")
+		flags.Int("max-guesses", 0, "")
+		flags.Var(&wordFlag{}, "word", "")
+		err := applyConfig(flags, flags, StringSet{})
+		if (err == nil) != c.ok {
+			t.Errorf("config %v gave error %v, want ok %v", c.json, err, c.ok)
+		}
+	}
+}
+
+func TestDecisionTreeWithNoGuess(t *testing.T) {
+	var tree *DecisionNode
+	playWith(t, "", []string{"crane", "slate"}, func() {
+		// Both words have been guessed, but no clues were taken from the
+		// responses, so both still fit and there is nothing left to guess.
+		solver := NewSolver()
+		solver.history = append(solver.history, TranscriptEntry{guess: "crane", response: "nnnnn"})
+		solver.history = append(solver.history, TranscriptEntry{guess: "slate", response: "nnnnn"})
+		tree = buildDecisionTree(solver, MAX_GUESSES)
+	})
+	if tree == nil || len(tree.Guess) > 0 || len(tree.Next) > 0 || len(tree.Unsolved) != 2 {
+		t.Errorf("with every word guessed, the tree was %+v, want crane and slate unsolved", tree)
+	}
+}
+
+func TestRegexEscapesAlphabet(t *testing.T) {
+	savedAlphabet := alphabet
+	alphabet = "abcde]^-\\."
+	defer func() { alphabet = savedAlphabet }()
+	solver := NewSolver()
+	solver.validLetters[0] = StringSet{".": true}
+	solver.validLetters[1] = StringSet{"a": true, "]": true, "^": true}
+	solver.validLetters[2] = StringSet{"a": true, "b": true, "c": true, "d": true, "e": true, "-": true}
+	solver.validLetters[3] = StringSet{"a": true, "\\": true}
+	re, err := regexp.Compile(solver.regex())
+	if err != nil {
+		t.Fatalf("regex %v does not compile: %v", solver.regex(), err)
+	}
+	cases := map[string]bool{
+		".]-\\e": true,
+		".^aab":  true,
+		"a]-\\e": false,
+		".b-\\e": false,
+		".]]\\e": false,
+		".]ab]":  false,
+	}
+	for word, matches := range cases {
+		if re.MatchString(word) != matches {
+			t.Errorf("regex %v matches %q: %v, want %v", solver.regex(), word, !matches, matches)
+		}
+	}
+}
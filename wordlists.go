@@ -0,0 +1,359 @@
+// wordlists.go - The word lists built into wordg, and word list files,
+// selectable with --list.
+
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The known words, one per line, most frequent first.  They come from
+// https://wortschatz.uni-leipzig.de/en/download/English, manually edited
+// to remove dubious entries.
+//
+//go:embed words5char.txt
+var englishWordsText string
+
+// The words that can be played: the english list until --list chooses
+// another.
+var AllWords = strings.Fields(englishWordsText)
+
+const DEFAULT_WORD_LIST = "english"
+
+// The number of the most frequent english words in the common list.
+const NUM_COMMON_WORDS = 1000
+
+// The names of the built-in word lists, and how to get each.
+var wordLists = map[string]func() []string{
+	"english": func() []string { return strings.Fields(englishWordsText) },
+	"common":  func() []string { return strings.Fields(englishWordsText)[:NUM_COMMON_WORDS] },
+}
+
+// Return the names of the built-in word lists, for messages.
+func wordListNames() string {
+	return strings.Join([]string{"english", "common"}, ", ")
+}
+
+// If more than this fraction of a word list is the wrong length, the
+// warning about it is printed even without --verbose.
+const SIGNIFICANT_DROP_FRACTION = 0.1
+
+// The position of each word in the word list as loaded, before --order
+// rearranges it.  The built-in lists are in order of frequency, so this
+// ranks words by how common they are.
+var wordRank = make(map[string]int)
+
+// The category of each word in the word list, such as animals, for the
+// words that have one.  Only word list files can give categories.
+var wordCategories = make(map[string]string)
+
+// The words capitalized in the word list, taken to be proper nouns.  They
+// are lowercased in AllWords, so they can be guessed like any other.
+var properNouns = make(StringSet)
+
+// Read a word list from a file, one word per line.  A word may be
+// followed by its category, as a second column.  Returns the words and
+// the categories of those that have one.
+func readWordListFile(path string) ([]string, map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var words []string
+	categories := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		words = append(words, fields[0])
+		if len(fields) > 1 {
+			categories[fields[0]] = strings.ToLower(fields[1])
+		}
+	}
+	return words, categories, nil
+}
+
+// Return the words in words that are LETTERS_IN_WORD letters long.
+// Lists that mix lengths are filtered rather than rejected, since words
+// of other lengths can't be played; if a significant part of the list
+// is dropped, or verbose is set, say how many words were dropped.
+func filterWordLength(name string, words []string, verbose bool) []string {
+	var kept []string
+	for _, word := range words {
+		if utf8.RuneCountInString(word) == LETTERS_IN_WORD {
+			kept = append(kept, word)
+		}
+	}
+	numDropped := len(words) - len(kept)
+	if numDropped > 0 && (verbose || float64(numDropped) > SIGNIFICANT_DROP_FRACTION*float64(len(words))) {
+		fmt.Printf("Warning: dropped %v of the %v words in %v that are not %v letters long\n",
+			numDropped, len(words), name, LETTERS_IN_WORD)
+	}
+	return kept
+}
+
+// Return words with any repeats removed, keeping the first copy of each,
+// and say so if there were any.  A repeated word would otherwise be
+// counted twice as a candidate and be twice as likely to be chosen.
+func dedupWords(name string, words []string) []string {
+	seen := make(StringSet)
+	var kept []string
+	for _, word := range words {
+		if !seen.Contains(word) {
+			seen.Add(word)
+			kept = append(kept, word)
+		}
+	}
+	if numDropped := len(words) - len(kept); numDropped > 0 {
+		fmt.Printf("Warning: dropped %v repeated words in %v\n", numDropped, name)
+	}
+	return kept
+}
+
+// Replace AllWords with the word list called name: either one of the
+// built-in lists or, if there is no built-in list by that name, a file.
+// If name is empty, the default list is used.
+func selectWordList(name string, verbose bool) error {
+	if len(name) == 0 {
+		name = DEFAULT_WORD_LIST
+	}
+	var words []string
+	categories := make(map[string]string)
+	if getList, present := wordLists[name]; present {
+		words = getList()
+	} else {
+		var err error
+		words, categories, err = readWordListFile(name)
+		if err != nil {
+			return fmt.Errorf("%v is neither a built-in word list (%v) nor a readable file: %v",
+				name, wordListNames(), err)
+		}
+	}
+	nouns := make(StringSet)
+	for i, word := range words {
+		if first, _ := utf8.DecodeRuneInString(word); unicode.IsUpper(first) {
+			words[i] = strings.ToLower(word)
+			nouns.Add(words[i])
+			if category, present := categories[word]; present {
+				categories[words[i]] = category
+			}
+		}
+	}
+	words = dedupWords(name, filterWordLength(name, words, verbose))
+	if len(words) == 0 {
+		return fmt.Errorf("word list %v has no words of %v letters", name, LETTERS_IN_WORD)
+	}
+	AllWords = words
+	wordCategories = categories
+	properNouns = nouns
+	wordRank = make(map[string]int)
+	for i, word := range words {
+		wordRank[word] = i
+	}
+	return nil
+}
+
+// Report whether every letter of word is in the alphabet.
+func inAlphabet(word string) bool {
+	for _, letter := range word {
+		if !strings.ContainsRune(alphabet, letter) {
+			return false
+		}
+	}
+	return true
+}
+
+// Check that every word in AllWords is made of letters of the alphabet.
+func checkAlphabet(name string) error {
+	for _, word := range AllWords {
+		if !inAlphabet(word) {
+			return fmt.Errorf("%v in word list %v has letters that are not in the alphabet %v",
+				word, name, alphabet)
+		}
+	}
+	return nil
+}
+
+// The name --category-weights uses for the words that have no category.
+const NO_CATEGORY = "other"
+
+// Return the category of word, or NO_CATEGORY.
+func categoryOf(word string) string {
+	if category, present := wordCategories[word]; present {
+		return category
+	}
+	return NO_CATEGORY
+}
+
+// Parse --category-weights, which is a comma-separated list of
+// category=weight, like "other=70,animals=30".  Weights are relative, so
+// they needn't add up to 100.
+func parseCategoryWeights(text string) (map[string]float64, error) {
+	weights := make(map[string]float64)
+	for _, item := range strings.Split(text, ",") {
+		category, weightText, found := strings.Cut(item, "=")
+		weight, err := strconv.ParseFloat(weightText, 64)
+		if !found || err != nil || weight < 0 {
+			return nil, fmt.Errorf("--category-weights must be category=weight,..., not %v", item)
+		}
+		weights[strings.ToLower(category)] = weight
+	}
+	return weights, nil
+}
+
+// Check that every category in weights has words in words.
+func checkCategoryWeights(words []string, weights map[string]float64) error {
+	counts := make(map[string]int)
+	for _, word := range words {
+		counts[categoryOf(word)]++
+	}
+	for category := range weights {
+		if counts[category] == 0 {
+			return fmt.Errorf("the word list has no words in category %v", category)
+		}
+	}
+	return nil
+}
+
+// Choose a word from words at random: first a category, with the chance
+// of each given by weights, then a word in that category with chooseWord.
+// Categories not in weights are never chosen.
+func chooseWeightedWord(words []string, weights map[string]float64) string {
+	byCategory := make(map[string][]string)
+	for _, word := range words {
+		byCategory[categoryOf(word)] = append(byCategory[categoryOf(word)], word)
+	}
+	// Go through the categories in a fixed order, so that --seed gives
+	// the same word every time.
+	var categories []string
+	total := 0.0
+	for category, weight := range weights {
+		categories = append(categories, category)
+		total += weight
+	}
+	sort.Strings(categories)
+	choice := rng.Float64() * total
+	for _, category := range categories {
+		choice -= weights[category]
+		if choice < 0 {
+			words := byCategory[category]
+			return chooseWord(words)
+		}
+	}
+	// Only reached if every weight is 0.
+	return chooseWord(words)
+}
+
+// Report whether word looks like a plural.  The lists hold only words of
+// LETTERS_IN_WORD letters, so the singular can't be looked up; instead a
+// word ending in s is taken to be a plural unless it ends in ss, us or
+// is, as class, bonus and basis do.  Verbs such as makes are caught too,
+// which Wordle doesn't use as answers either.
+func isLikelyPlural(word string) bool {
+	return strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") &&
+		!strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is")
+}
+
+// Return the words the secret word in --run mode can be.  That is all of
+// AllWords that fit the --locked pattern locked, if there is one.  If
+// noPlurals is set, plurals and proper nouns are left out too, as Wordle
+// leaves them out of its answers.  They can still be guessed.
+func answerPool(noPlurals bool, locked string) []string {
+	var words []string
+	seen := make(StringSet)
+	for _, word := range AllWords {
+		// AllWords is deduplicated when it's loaded, but a repeat must
+		// not make a word more likely to be chosen even if it isn't.
+		if seen.Contains(word) {
+			continue
+		}
+		seen.Add(word)
+		if noPlurals && (isLikelyPlural(word) || properNouns.Contains(word)) {
+			continue
+		}
+		if fitsLocked(word, locked) {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// Restrict AllWords, and so both the secret word and the guesses allowed,
+// to the words in category.
+func selectCategory(category string) error {
+	category = strings.ToLower(category)
+	var words []string
+	for _, word := range AllWords {
+		if wordCategories[word] == category {
+			words = append(words, word)
+		}
+	}
+	if len(words) == 0 {
+		return fmt.Errorf("the word list has no words in category %v", category)
+	}
+	AllWords = words
+	return nil
+}
+
+// A letter and how many times it was counted.
+type LetterCount struct {
+	letter string
+	count  int
+}
+
+// Return the letters in counts, most frequent first.  Letters with the
+// same count are in alphabetical order.
+func rankLetters(counts map[string]int) []LetterCount {
+	var ranked []LetterCount
+	for letter, count := range counts {
+		ranked = append(ranked, LetterCount{letter: letter, count: count})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].count != ranked[j].count {
+			return ranked[i].count > ranked[j].count
+		}
+		return ranked[i].letter < ranked[j].letter
+	})
+	return ranked
+}
+
+// Print some statistics about the word list in use.
+func printWordListStats(name string) {
+	letterCounts := make(map[string]int)
+	firstLetterCounts := make(map[string]int)
+	numWithDuplicates := 0
+	for _, word := range AllWords {
+		mapLetterToCount := makeMapFromWord(word)
+		if len(mapLetterToCount) < utf8.RuneCountInString(word) {
+			numWithDuplicates++
+		}
+		for letter, count := range mapLetterToCount {
+			letterCounts[letter] += count
+		}
+		firstLetter, _ := utf8.DecodeRuneInString(word)
+		firstLetterCounts[string(firstLetter)]++
+	}
+
+	fmt.Printf("Word list %v: %v words, %v with repeated letters\n", name, len(AllWords), numWithDuplicates)
+	letters := ""
+	for _, entry := range rankLetters(letterCounts) {
+		letters += entry.letter
+	}
+	fmt.Println("Letters, most frequent first: " + letters)
+	msg := "Most common first letters:"
+	for i, entry := range rankLetters(firstLetterCounts) {
+		if i == 5 {
+			break
+		}
+		msg += fmt.Sprintf(" %v (%v)", entry.letter, entry.count)
+	}
+	fmt.Println(msg)
+}
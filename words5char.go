@@ -1,361 +0,0 @@
-package main
-
-// This list of known words comes from https://wortschatz.uni-leipzig.de/en/download/English
-// and manually edited to remove dubious entries.
-// It was converted from its original format by extractfromwordlist.awk.
-var AllWords = []string{
-	"their", "about", "which", "would", "after", "other", "there", "first",
-	"could", "being", "years", "those", "while", "where", "going", "still",
-	"these", "state", "cases", "three", "think", "since", "right", "under",
-	"world", "place", "added", "virus", "every", "never", "found", "local",
-	"until", "might", "great", "month", "weeks", "early", "group", "later",
-	"media", "times", "money", "start", "again", "asked", "doing", "order",
-	"night", "least", "total", "death", "given", "staff", "comes", "today",
-	"using", "taken", "women", "close", "point", "price", "among", "along",
-	"video", "thing", "power", "hours", "small", "games", "stock", "court",
-	"known", "needs", "young", "event", "major", "party", "means", "lives",
-	"story", "water", "third", "makes", "large", "level", "share", "shows",
-	"based", "plans", "final", "often", "began", "clear", "bring", "areas",
-	"woman", "house", "issue", "space", "worth", "allow", "front", "board",
-	"wrote", "music", "ahead", "masks", "daily", "whole", "seven", "human",
-	"black", "fight", "offer", "chief", "leave", "phone", "short", "teams",
-	"child", "light", "study", "wants", "ready", "takes", "white", "above",
-	"quite", "movie", "scene", "below", "homes", "terms", "moved", "sense",
-	"field", "seems", "noted", "press", "trade", "watch", "tests", "focus",
-	"rules", "coach", "hands", "legal", "lower", "works", "spent", "eight",
-	"cause", "force", "visit", "calls", "store", "whose", "words", "stage",
-	"looks", "match", "value", "happy", "begin", "build", "heart", "heard",
-	"tried", "break", "drive", "serve", "learn", "reach", "range", "rates",
-	"stand", "model", "sales", "ended", "parts", "check", "funds", "track",
-	"avoid", "ratio", "wrong", "class", "basis", "title", "trial", "built",
-	"round", "gives", "votes", "extra", "named", "items", "crime", "loved",
-	"alone", "claim", "email", "users", "costs", "cover", "novel", "spoke",
-	"spend", "feels", "fully", "meant", "knows", "photo", "trust", "speak",
-	"girls", "tough", "yards", "actor", "worst", "steps", "table", "voice",
-	"block", "blood", "brand", "filed", "enjoy", "hopes", "peace", "quick",
-	"shown", "carry", "talks", "owner", "paper", "speed", "judge", "aware",
-	"civil", "risks", "rally", "piece", "prior", "lines", "crowd", "goals",
-	"miles", "older", "plays", "maybe", "helps", "urged", "films", "stake",
-	"faced", "lived", "raise", "clean", "count", "score", "fresh", "sides",
-	"worse", "truth", "apply", "heavy", "image", "stuff", "hotel", "sites",
-	"doubt", "album", "crore", "truly", "north", "twice", "abuse", "drugs",
-	"floor", "limit", "proud", "signs", "sound", "broke", "ideas", "prime",
-	"stars", "tells", "train", "write", "apart", "deals", "agree", "books",
-	"enter", "green", "thank", "youth", "radio", "touch", "plant", "joint",
-	"surge", "faces", "scale", "songs", "crash", "goods", "south", "taxes",
-	"banks", "owned", "shots", "doors", "giant", "loans", "color", "leads",
-	"treat", "polls", "union", "units", "voted", "basic", "shift", "clubs",
-	"entry", "names", "smart", "prove", "tools", "boost", "rural", "trend",
-	"cards", "chain", "draft", "phase", "seats", "false", "views", "armed",
-	"award", "guard", "roads", "style", "mayor", "sport", "stood", "beach",
-	"dream", "waste", "truck", "usual", "catch", "aside", "faith", "notes",
-	"panel", "fewer", "fraud", "guess", "roles", "solid", "worry", "fired",
-	"grant", "holds", "moves", "route", "tweet", "dance", "fifth", "folks",
-	"plane", "sleep", "vital", "forms", "grown", "turns", "storm", "stuck",
-	"debut", "empty", "theme", "honor", "drama", "fears", "likes", "shall",
-	"adult", "begun", "shops", "throw", "glass", "gonna", "urban", "alive",
-	"cells", "aimed", "index", "newly", "posts", "shape", "shoot", "spike",
-	"chose", "cross", "firms", "agent", "coast", "super", "types", "viral",
-	"delay", "split", "birth", "crazy", "dress", "keeps", "parks", "rooms",
-	"royal", "stick", "finds", "meals", "voter", "bills", "brain", "equal",
-	"guide", "rated", "sharp", "tight", "blame", "exist", "heads", "pitch",
-	"tally", "boxes", "chair", "cycle", "dates", "races", "threw", "minor",
-	"trees", "facts", "favor", "dying", "fires", "ruled", "spots", "sweet",
-	"anger", "cited", "marks", "mixed", "pilot", "rapid", "drink", "grade",
-	"squad", "tries", "winds", "proof", "tears", "depth", "fixed", "loves",
-	"quiet", "trail", "cloud", "funny", "saved", "upper", "alert", "dozen",
-	"drawn", "gains", "posed", "yield", "labor", "occur", "shoes", "brief",
-	"minds", "pages", "teach", "chart", "kinds", "liked", "lucky", "noise",
-	"nurse", "rival", "shock", "trips", "walls", "angry", "cable", "eager",
-	"guest", "hired", "mouth", "panic", "picks", "safer", "sixth", "smoke",
-	"solar", "sorry", "lying", "swing", "unity", "buses", "curve", "grand",
-	"lunch", "smile", "wages", "wider", "asset", "broad", "brown", "falls",
-	"frame", "paint", "reads", "taste", "comic", "hosts", "magic", "sugar",
-	"theft", "weird", "admit", "drove", "flood", "horse", "maker", "ocean",
-	"ships", "stops", "aired", "audio", "fever", "motor", "peers", "skill",
-	"alarm", "camps", "chest", "clock", "cream", "links", "plate", "probe",
-	"ranks", "steal", "upset", "acres", "argue", "bench", "crude", "drone",
-	"gross", "ideal", "moral", "roots", "usage", "anime", "bunch", "crops",
-	"globe", "laugh", "meets", "opted", "solve", "venue", "waves", "bonds",
-	"chaos", "civic", "crews", "error", "knife", "metal", "opens", "cheap",
-	"farms", "fatal", "input", "river", "scary", "seeks", "sight", "topic",
-	"valid", "cents", "exams", "forth", "lands", "shirt", "stays", "towns",
-	"walks", "zones", "adapt", "elite", "enemy", "balls", "bases", "climb",
-	"dated", "doses", "grief", "hence", "highs", "shame", "sizes", "wound",
-	"blast", "bread", "clash", "earth", "fines", "genre", "holes", "hoped",
-	"march", "pride", "swept", "tired", "badly", "dairy", "fruit", "loose",
-	"midst", "alike", "bound", "label", "ninth", "sheet", "steel", "audit",
-	"cargo", "dealt", "files", "fleet", "print", "rugby", "thick", "vocal",
-	"adopt", "beans", "click", "exact", "foods", "grass", "rough", "vowed",
-	"cyber", "kills", "poses", "seeds", "tours", "toxic", "wheel", "acted",
-	"fault", "forum", "gifts", "layer", "loses", "ports", "prize", "saves",
-	"sunny", "teeth", "heels", "packs", "tends", "trash", "backs", "candy",
-	"cloth", "coins", "grace", "grain", "loyal", "pause", "risen", "setup",
-	"shake", "spell", "strip", "tanks", "uncle", "bears", "blind", "bonus",
-	"cares", "cheer", "chips", "humor", "inner", "joins", "lease", "pants",
-	"reply", "trick", "vegan", "acute", "awful", "birds", "blend", "brave",
-	"burst", "chase", "craft", "fifty", "naked", "norms", "owing", "pizza",
-	"quest", "rains", "refer", "sadly", "wards", "bands", "cared", "couch",
-	"halls", "hedge", "lined", "lists", "pairs", "risky", "sauce", "slide",
-	"spray", "stole", "tense", "token", "boats", "cater", "drops", "fence",
-	"fined", "havoc", "lungs", "plain", "rings", "smell", "sorts", "suits",
-	"teens", "boots", "cough", "drill", "emoji", "grave", "jokes", "lanes",
-	"ought", "punch", "queen", "quote", "react", "rifle", "scope", "shade",
-	"shine", "shore", "suing", "weigh", "cabin", "crack", "dirty", "drunk",
-	"males", "medal", "nerve", "rolls", "salad", "steep", "stint", "tasks",
-	"tuned", "urges", "wrist", "arena", "crown", "eased", "elect", "flown",
-	"flows", "grows", "kicks", "knock", "newer", "onion", "paths", "radar",
-	"raped", "relax", "sends", "sheer", "stamp", "stark", "stats", "sworn",
-	"trace", "wears", "yours", "abide", "ankle", "array", "await", "batch",
-	"blaze", "booth", "brush", "cease", "clerk", "codes", "dough", "draws",
-	"edges", "fails", "lacks", "lobby", "merit", "orbit", "pulls", "rocks",
-	"shell", "silly", "spare", "spark", "stone", "swift", "warns", "aides",
-	"cruel", "euros", "fancy", "flags", "fluid", "harsh", "honey", "laser",
-	"logic", "niece", "opera", "patio", "piano", "pound", "riots", "rises",
-	"sacks", "steam", "sweat", "sweep", "trans", "woods", "ample", "angle",
-	"burnt", "elbow", "gates", "hefty", "joked", "modes", "mural", "naval",
-	"patch", "proxy", "savvy", "slots", "slump", "snaps", "suite", "twist",
-	"wanna", "wings", "wipes", "wraps", "aging", "bikes", "boast", "bones",
-	"bored", "cafes", "charm", "cooks", "fibre", "flaws", "flour", "fuels",
-	"glory", "guilt", "gusts", "nasty", "organ", "outer", "sells", "sewer",
-	"sheep", "spite", "stack", "stems", "tents", "tribe", "vague", "blank",
-	"blown", "buddy", "buyer", "ethos", "flies", "ghost", "juice", "knees",
-	"liver", "locks", "lover", "manga", "marry", "memes", "onset", "penny",
-	"realm", "reign", "rides", "ruler", "scams", "scans", "scare", "shelf",
-	"shook", "slate", "verge", "wells", "wheat", "wiped", "beats", "belly",
-	"brick", "clips", "crush", "drain", "fares", "ferry", "flash", "grind",
-	"handy", "loser", "metre", "mount", "overs", "pains", "pivot", "porch",
-	"prone", "queer", "queue", "rents", "slice", "snack", "snake", "souls",
-	"stall", "sword", "texts", "alien", "align", "arise", "armor", "bombs",
-	"bucks", "cameo", "cheat", "chunk", "cried", "dense", "dried", "dryer",
-	"elder", "erase", "forge", "greet", "hears", "hurts", "mercy", "meter",
-	"metro", "minus", "pasta", "paste", "purse", "raced", "raids", "rebel",
-	"salon", "sings", "sober", "spine", "stunt", "tiger", "tires", "unite",
-	"utter", "vigil", "wines", "wives", "aided", "alter", "arose", "beast",
-	"bleak", "booty", "brink", "chats", "chess", "covid", "debts", "defer",
-	"desks", "deter", "earns", "edged", "essay", "excel", "famed", "freed",
-	"grasp", "haunt", "hikes", "jails", "kinda", "layup", "lemon", "mills",
-	"mourn", "perks", "plead", "pools", "ranch", "renew", "robot", "rumor",
-	"slave", "waist", "weary", "aisle", "avail", "bacon", "bless", "blitz",
-	"blunt", "bully", "chill", "choir", "cliff", "corps", "cubic", "cured",
-	"devil", "diary", "ditch", "donor", "downs", "drift", "enact", "equip",
-	"flock", "fried", "gangs", "gauge", "gotta", "habit", "hails", "harms",
-	"hated", "hills", "hints", "lakes", "lasts", "loads", "looms", "messy",
-	"mines", "notch", "ounce", "paced", "plaza", "promo", "relay", "reset",
-	"seize", "slept", "slope", "smash", "spice", "swabs", "tales", "tasty",
-	"thigh", "toned", "tooth", "tower", "tubes", "tumor", "tunes", "twins",
-	"upped", "valve", "widow", "wreck", "agile", "binge", "blows", "brass",
-	"choke", "cites", "cocoa", "coral", "demon", "diets", "eaten", "ethic",
-	"fiery", "flame", "flats", "germs", "grabs", "grams", "idiot", "imply",
-	"jeans", "lifts", "login", "menus", "myths", "naive", "needy", "niche",
-	"peaks", "piled", "plots", "poles", "pulse", "quota", "rainy", "rehab",
-	"skies", "skirt", "slick", "socks", "spill", "steer", "swipe", "tiers",
-	"toast", "troll", "yacht", "aches", "alley", "angel", "apple", "apron",
-	"belts", "breed", "bulbs", "cakes", "canon", "casts", "clues", "crest",
-	"curbs", "curry", "derby", "drums", "eerie", "exits", "feeds", "flesh",
-	"gears", "hangs", "homer", "horns", "leaks", "limbo", "limbs", "litre",
-	"maize", "malls", "matte", "meats", "mouse", "nails", "noble", "noses",
-	"pests", "pills", "pleas", "rabbi", "repay", "rider", "roast", "rodeo",
-	"shout", "sized", "slams", "slows", "sneak", "spans", "spate", "specs",
-	"sting", "tease", "tenth", "villa", "vying", "waits", "woven", "alias",
-	"amend", "baked", "beard", "bites", "blues", "borne", "boxer", "bribe",
-	"bumps", "champ", "cheek", "condo", "debit", "drown", "ducks", "duvet",
-	"facet", "forgo", "gamer", "geese", "goofy", "graft", "greed", "groom",
-	"guise", "heist", "herbs", "icons", "indie", "irony", "jumps", "knelt",
-	"lakhs", "legit", "mafia", "maple", "motto", "paved", "penis", "pesky",
-	"petty", "pipes", "poker", "polio", "ramen", "ramps", "recap", "rests",
-	"rogue", "saint", "scout", "scrap", "shark", "shiat", "sinks", "slurs",
-	"spoil", "spree", "stiff", "stool", "straw", "tacos", "taped", "tapes",
-	"thugs", "thumb", "timed", "tonne", "torso", "tract", "traps", "truce",
-	"tying", "veins", "vibes", "vivid", "waive", "whale", "width", "windy",
-	"wires", "wrath", "altar", "arson", "avert", "awake", "beers", "bezel",
-	"blade", "bleed", "blond", "blush", "bowls", "brace", "brawl", "brisk",
-	"burns", "canal", "carts", "chefs", "clown", "coped", "corny", "crawl",
-	"cries", "curls", "curly", "deems", "dives", "dolls", "dread", "envoy",
-	"erupt", "faded", "fairs", "fairy", "feast", "fiber", "fills", "footy",
-	"foray", "forty", "frank", "freak", "fused", "gazed", "gloss", "glove",
-	"graph", "grill", "hobby", "inbox", "inept", "juicy", "knots", "koala",
-	"leans", "leapt", "lends", "lodge", "lunar", "lured", "micro", "mimic",
-	"mogul", "mound", "muted", "noisy", "petri", "poems", "pooch", "prank",
-	"pumps", "puppy", "reuse", "ridge", "rinse", "rocky", "roofs", "roses",
-	"sedan", "shady", "shred", "silky", "siren", "slips", "soars", "spicy",
-	"strap", "stray", "swear", "swell", "swine", "swung", "taxis", "temps",
-	"tones", "troop", "trunk", "vents", "versa", "vinyl", "wakes", "whisk",
-	"widen", "wield", "wired", "witch", "woken", "aback", "abort", "adore",
-	"agony", "aloud", "angst", "awash", "awoke", "badge", "baker", "baton",
-	"bells", "bible", "booze", "bowed", "bowel", "bride", "brunt", "bulls",
-	"bylaw", "cages", "carve", "caved", "chime", "chops", "cling", "clone",
-	"coats", "colon", "combo", "crave", "curse", "dared", "dodge", "dorms",
-	"fella", "fetch", "fixes", "flare", "flick", "forex", "fungi", "furry",
-	"ganja", "gases", "genes", "gloom", "goats", "goers", "gowns", "hatch",
-	"haven", "hides", "homey", "humid", "laden", "leafy", "leaps", "liars",
-	"libel", "lofty", "lousy", "lyric", "memos", "merge", "mixer", "motel",
-	"nasal", "ninja", "obese", "olive", "ozone", "peach", "peril", "pints",
-	"plush", "polar", "ponds", "pupil", "quell", "raged", "rails", "reefs",
-	"retro", "ropes", "scars", "shiny", "shone", "shrug", "slack", "sleek",
-	"snowy", "sonar", "sperm", "stain", "steak", "stern", "stove", "swore",
-	"synth", "taxed", "thong", "thorn", "tiles", "tombs", "towed", "towel",
-	"trait", "trove", "turbo", "tweak", "venom", "vests", "visas", "vomit",
-	"weave", "algae", "annum", "ashes", "attic", "bison", "bogus", "booed",
-	"bouts", "brute", "bumpy", "chalk", "chant", "churn", "circa", "cloak",
-	"clout", "comet", "comfy", "cones", "crank", "creep", "crisp", "crust",
-	"cures", "darts", "decks", "denim", "depot", "detox", "digit", "docks",
-	"dunes", "elves", "enrol", "erode", "exile", "facto", "faint", "fatty",
-	"feral", "float", "flush", "focal", "foggy", "folds", "fouls", "frost",
-	"gated", "grape", "graze", "grips", "groin", "gusty", "hacks", "hasty",
-	"hates", "hello", "honed", "icing", "ivory", "jelly", "jolly", "kings",
-	"lapse", "lawns", "lidar", "macho", "macro", "mains", "manic", "mated",
-	"maths", "medic", "mince", "moons", "moron", "morph", "mould", "nodal",
-	"nodes", "oddly", "outta", "overt", "pedal", "pixel", "plume", "poets",
-	"pokes", "prose", "quake", "quips", "rages", "raked", "reins", "robes",
-	"rover", "rower", "ruins", "runny", "rupee", "scalp", "scent", "scoop",
-	"shack", "shale", "sheds", "shied", "shove", "siege", "skate", "skull",
-	"smear", "soups", "spelt", "spins", "stash", "stave", "sushi", "swamp",
-	"swirl", "swoop", "terse", "tilts", "tithe", "tolls", "touts", "tread",
-	"trout", "ultra", "undue", "unify", "usher", "vapor", "vault", "wacky",
-	"waged", "wagon", "warms", "wiser", "adept", "aegis", "ahold", "akong",
-	"allay", "alloy", "amass", "aroma", "arrow", "bales", "bared", "baths",
-	"beets", "berth", "blocs", "blogs", "bloom", "blurb", "bosom", "brake",
-	"broth", "bulky", "buoys", "busts", "butts", "cadet", "calms", "capex",
-	"cedar", "chasm", "chili", "chock", "cigar", "clogs", "clots", "coded",
-	"crane", "crass", "craze", "creed", "crows", "cubes", "decay", "decoy",
-	"deeds", "diced", "disco", "discs", "dodgy", "dogma", "douse", "drags",
-	"drams", "duels", "dusty", "ebbed", "evade", "evils", "exert", "fades",
-	"fared", "fauna", "fetal", "feted", "feuds", "filly", "fists", "flair",
-	"flank", "flask", "fluff", "fools", "fours", "frail", "fries", "frown",
-	"fudge", "garda", "grate", "gravy", "grimy", "hairs", "hawks", "heaps",
-	"hilly", "hitch", "holed", "hoods", "hoses", "hotly", "idled", "idols",
-	"inked", "intro", "irked", "irons", "itchy", "jacks", "jerky", "jihad",
-	"kinks", "kneel", "knell", "laces", "lambs", "lanky", "leaky", "ledge",
-	"levee", "lever", "lilac", "lions", "locum", "lorry", "lumps", "mango",
-	"marsh", "masse", "mates", "maxed", "melee", "miner", "mired", "mixes",
-	"moods", "moths", "muddy", "musty", "naira", "nanny", "nests", "nexus",
-	"nylon", "optic", "oxide", "palsy", "pearl", "perch", "picky", "pinch",
-	"plugs", "plump", "poked", "polka", "prays", "proms", "prowl", "query",
-	"quits", "racer", "raved", "razor", "rejig", "relic", "remit", "rigid",
-	"rowdy", "runes", "salsa", "sandy", "scant", "scorn", "screw", "scrub",
-	"scrum", "seals", "seams", "semis", "shave", "sixes", "skins", "skips",
-	"slain", "smoky", "snuck", "soaks", "soils", "sonic", "spoon", "spurs",
-	"spurt", "stale", "stead", "stink", "stoke", "stomp", "stoop", "stump",
-	"stung", "sucks", "syrup", "taser", "teddy", "telco", "tepid", "thief",
-	"tidal", "titan", "torch", "toxin", "trams", "tummy", "tyres", "unfit",
-	"unmet", "urine", "vitro", "weeds", "whims", "wills", "witty", "worms",
-	"wreak", "wrest", "yeast", "yummy", "zonal", "zoned", "abate", "abyss",
-	"affix", "alibi", "alpha", "alums", "amber", "ambit", "amiss", "amply",
-	"arses", "atoms", "avian", "axing", "bagel", "barns", "basin", "beige",
-	"bends", "berry", "beset", "biden", "bidet", "billy", "bingo", "bitch",
-	"bloke", "bodes", "bogey", "bolts", "booms", "boozy", "boxed", "braai",
-	"braid", "brash", "brawn", "brine", "budge", "buffs", "buggy", "bunny",
-	"cabal", "cadre", "camel", "canoe", "carbs", "carte", "caste", "cedis",
-	"celeb", "cello", "chewy", "chick", "chimp", "chore", "cider", "clamp",
-	"claws", "clove", "clump", "clung", "colds", "cords", "cores", "coven",
-	"cower", "crabs", "cramp", "crate", "creek", "crimp", "crook", "curvy",
-	"cuter", "decor", "deity", "dello", "delta", "delve", "dined", "dived",
-	"divvy", "doled", "donut", "doozy", "drank", "drool", "dudes", "dunks",
-	"duped", "durag", "dwarf", "dwell", "eagle", "edict", "elude", "ember",
-	"emits", "ensue", "erect", "execs", "exude", "facie", "faked", "fakes",
-	"fangs", "farce", "feats", "felon", "fides", "filet", "fling", "floss",
-	"flout", "flyer", "folly", "forks", "foyer", "froze", "funky", "furor",
-	"fussy", "fuzzy", "gamma", "gamut", "genus", "glare", "glaze", "gleam",
-	"gloat", "glued", "goner", "goose", "gorge", "grits", "grunt", "hairy",
-	"halts", "halve", "hardy", "heady", "herds", "hiked", "hindi", "hinge",
-	"hires", "hound", "hover", "hubby", "hunch", "hunts", "hurry", "hydro",
-	"hyped", "incur", "intel", "intra", "isles", "janky", "jerks", "juror",
-	"karma", "kudos", "laced", "lamps", "latex", "leash", "lefty", "liner",
-	"lipid", "liter", "locus", "loner", "lords", "lucid", "lurks", "mandi",
-	"masts", "matza", "maxim", "meaty", "melts", "merch", "meted", "mined",
-	"minks", "modem", "moody", "moors", "mower", "mucus", "mulch", "murky",
-	"mused", "nadir", "nears", "necks", "nerds", "nifty", "nigga", "ninyo",
-	"noose", "nukes", "oaths", "obeys", "okada", "olden", "opine", "opium",
-	"orcas", "outgo", "paces", "pagan", "pales", "palms", "panda", "pared",
-	"pasts", "pesto", "petit", "phony", "piggy", "piles", "pings", "pithy",
-	"pores", "potty", "prima", "prong", "props", "prune", "psych", "purge",
-	"quads", "quirk", "rabid", "racks", "rakes", "rapes", "rarer", "rasas",
-	"raves", "razed", "rebut", "reels", "regal", "remix", "resin", "robin",
-	"roust", "rusty", "saath", "safes", "sails", "salty", "salve", "salvo",
-	"sauna", "saute", "scaly", "scarf", "scion", "scour", "seder", "seeps",
-	"serum", "sever", "sexes", "shaky", "shuls", "sided", "silos", "skews",
-	"slabs", "slash", "sleds", "slime", "sling", "slums", "sneer", "snuff",
-	"soles", "sores", "spies", "squat", "staid", "stalk", "stent", "stews",
-	"stoic", "strut", "suave", "surly", "swarm", "swath", "swoon", "taboo",
-	"taint", "tamed", "tarot", "tempo", "tenet", "ticks", "tides", "tills",
-	"timer", "tonal", "tongs", "trays", "trope", "trump", "tusks", "tutor",
-	"tweed", "typed", "upend", "usurp", "valet", "veers", "verse", "vexed",
-	"vicar", "vines", "vodka", "vogue", "waded", "waltz", "waned", "wares",
-	"waxes", "wedge", "wharf", "whine", "wisps", "wispy", "woody", "yearn",
-	"zebra", "zesty", "abbey", "abhor", "abode", "acids", "adhoc", "afoot",
-	"afoul", "agave", "aioli", "aleck", "aloft", "aloof", "amino", "amuse",
-	"anise", "annex", "annul", "antsy", "apace", "aping", "aptly", "argon",
-	"asian", "asses", "atone", "auger", "aunts", "autos", "aways", "baggy",
-	"bakes", "balmy", "bangs", "barbs", "barge", "baron", "basil", "bated",
-	"bathe", "beads", "beefs", "beefy", "beeps", "bests", "bight", "biker",
-	"binds", "birch", "bland", "blare", "bleat", "bleep", "bling", "blink",
-	"blips", "blobs", "bluer", "bluff", "blurt", "boars", "bobby", "boils",
-	"bongo", "bores", "brags", "brood", "broom", "burly", "bused", "buzzy",
-	"bytes", "cache", "cacti", "caked", "campy", "capes", "carat", "cased",
-	"catty", "caves", "ceded", "chads", "chafe", "chaps", "chemo", "chile",
-	"china", "chits", "chuck", "chums", "chute", "citys", "civet", "clams",
-	"clang", "claps", "cleft", "clink", "cluck", "clued", "coals", "cocky",
-	"colts", "combs", "cools", "cored", "corer", "coupe", "crept", "crier",
-	"cumin", "cysts", "daddy", "daisy", "dally", "dazed", "deans", "deice",
-	"deify", "demos", "dents", "dials", "dicey", "dimly", "diner", "dings",
-	"dingy", "disks", "ditty", "diver", "dizzy", "dobbs", "doles", "domed",
-	"domes", "dooms", "dorky", "drawl", "drier", "dries", "drips", "droid",
-	"dryly", "duets", "dumps", "dyers", "eases", "eater", "eaves", "edema",
-	"elegy", "elope", "elven", "embed", "epics", "epoch", "erred", "ether",
-	"evens", "evict", "evoke", "exalt", "expat", "expel", "fable", "famer",
-	"fates", "fecal", "feign", "feint", "fends", "ferns", "fetus", "filth",
-	"finer", "fiver", "fives", "fjord", "flack", "flaky", "flier", "flint",
-	"flips", "flora", "flubs", "fluke", "flung", "flute", "flyby", "foils",
-	"forte", "freer", "frees", "friar", "frisk", "frock", "frogs", "froth",
-	"fryer", "fumed", "fuses", "gator", "gaudy", "gazes", "gecko", "giddy",
-	"gills", "girly", "giver", "glide", "gnats", "goads", "gonzo", "goons",
-	"gourd", "grail", "grids", "grime", "gripe", "groan", "gulab", "gully",
-	"gumbo", "gummy", "gurus", "gypsy", "gyros", "haiku", "halal", "harem",
-	"haste", "hauls", "hazel", "heals", "heath", "heats", "heeds", "hella",
-	"hijab", "hindu", "hippo", "hippy", "hoard", "hoist", "hokey", "hooks",
-	"hoops", "horde", "hosed", "hulls", "humps", "humpy", "hyena", "hymns",
-	"idler", "imams", "inter", "ionic", "irate", "japan", "jewel", "jocks",
-	"joeys", "jowls", "jumbo", "kasus", "kayak", "kebab", "keyed", "kiddo",
-	"kilns", "kilos", "kinky", "kiosk", "kitty", "kiwis", "knack", "knead",
-	"kneed", "knobs", "krill", "larva", "latch", "latte", "leant", "lifer",
-	"limes", "lobes", "logos", "longs", "loons", "loony", "loops", "loots",
-	"lotta", "lotus", "lowly", "lunge", "lupus", "lures", "lurid", "lutes",
-	"lymph", "lynch", "madam", "madly", "maids", "mails", "manna", "manor",
-	"mater", "mauve", "maven", "mecca", "milky", "misty", "mitts", "moist",
-	"moldy", "momma", "monks", "moose", "moped", "morel", "mores", "mowed",
-	"mulls", "mummy", "mushy", "mutes", "nagar", "naman", "namul", "natal",
-	"navel", "nerdy", "nicer", "nitro", "noons", "nudes", "nudge", "nudie",
-	"oases", "oasis", "odors", "offed", "ontop", "outed", "ovary", "oxime",
-	"paddy", "paean", "pager", "pangs", "papal", "parry", "pasty", "patta",
-	"patty", "paves", "pawns", "payed", "payer", "peaky", "pears", "pecan",
-	"pelts", "pence", "peony", "perms", "perps", "pesos", "phish", "piers",
-	"pinup", "pious", "piped", "piper", "pitas", "plaid", "plait", "plasm",
-	"plows", "ploys", "pluck", "plums", "poach", "poise", "pokey", "polos",
-	"poppy", "posse", "pouch", "pours", "prang", "prawn", "preys", "prick",
-	"pries", "prism", "pubic", "pucks", "puffy", "pulpy", "pumas", "punts",
-	"puree", "pussy", "pyros", "quark", "quasi", "quill", "quilt", "radon",
-	"rafts", "raita", "rants", "reeds", "reeks", "revel", "revue", "rhino",
-	"riffs", "rigor", "rinks", "ritzy", "roars", "roomy", "rowie", "ruble",
-	"ruffs", "rumba", "rungs", "ruses", "saber", "salem", "saris", "satin",
-	"satyr", "saucy", "savor", "scoot", "scuba", "segue", "sehri", "semen",
-	"sewed", "shaft", "shard", "shawl", "shawn", "sheen", "shies", "shill",
-	"shire", "shiva", "shoal", "shuck", "shuns", "shuru", "shuts", "sicko",
-	"sieve", "sighs", "sired", "sisal", "sited", "sixty", "skier", "skimp",
-	"skits", "skunk", "slant", "slaps", "sloth", "slung", "slurp", "smack",
-	"smelt", "smirk", "smite", "smogs", "snare", "snarl", "snide", "sniff",
-	"snipe", "snips", "snout", "soaps", "soggy", "soley", "solos", "sooty",
-	"sours", "spade", "spars", "spawn", "spear", "speck", "spews", "spied",
-	"spiel", "spiny", "spits", "spool", "sprig", "spuds", "squid", "stabs",
-	"stair", "stare", "stipe", "stony", "stout", "studs", "sucky", "suede",
-	"surer", "swaps", "sways", "swims", "tacky", "taffy", "tails", "taker",
-	"tamer", "tamps", "tango", "tangy", "tarts", "tased", "tawny", "teats",
-	"telly", "tenor", "testy", "timid", "tinny", "toads", "topsy", "totes",
-	"treed", "treys", "trims", "trios", "tripe", "trite", "tryst", "tulip",
-	"twigs", "twill", "twine", "ulcer", "undid", "unsee", "usury", "utero",
-	"valor", "vapes", "vapid", "vials", "vices", "vixen", "voids", "voles",
-	"volts", "vouch", "vulva", "wacko", "wafts", "wager", "warps", "warts",
-	"wasnt", "watts", "waved", "waxed", "weepy", "welts", "whack", "whats",
-	"whiff", "whizz", "whois", "wilds", "wiles", "wince", "winks", "wombs",
-	"wonky", "wrung", "wryly", "yahoo", "yells",
-}